@@ -6,6 +6,8 @@ import (
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ginbear/k8s-flowtop/internal/audit"
+	"github.com/ginbear/k8s-flowtop/internal/config"
 	"github.com/ginbear/k8s-flowtop/internal/k8s"
 	"github.com/ginbear/k8s-flowtop/internal/tui"
 )
@@ -14,6 +16,7 @@ var (
 	version   = "dev"
 	namespace = flag.String("n", "", "Kubernetes namespace (empty for all namespaces)")
 	showVer   = flag.Bool("v", false, "Show version")
+	readOnly  = flag.Bool("readonly", false, "Disable all write actions")
 )
 
 func main() {
@@ -24,13 +27,31 @@ func main() {
 		os.Exit(0)
 	}
 
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+	cfg.ReadOnly = *readOnly
+	if cfg.AuditLog == "" {
+		if cfg.AuditLog, err = audit.DefaultPath(); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to locate audit log: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if err := k8s.RegisterKinds(cfg.Kinds); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid kinds in config: %v\n", err)
+		os.Exit(1)
+	}
+
 	client, err := k8s.NewClient(*namespace)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to create k8s client: %v\n", err)
 		os.Exit(1)
 	}
 
-	model := tui.NewModel(client)
+	model := tui.NewModel(client, cfg)
 	p := tea.NewProgram(model, tea.WithAltScreen())
 
 	if _, err := p.Run(); err != nil {
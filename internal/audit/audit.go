@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Result values of an Entry
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+// Entry is a single action performed through flowtop
+type Entry struct {
+	Time      time.Time `json:"time"`
+	Context   string    `json:"context"`
+	User      string    `json:"user"`
+	Verb      string    `json:"verb"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Created   string    `json:"created,omitempty"` // name of the resource created by the action
+	Result    string    `json:"result"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Log is an append-only JSONL audit file
+type Log struct {
+	path string
+}
+
+// DefaultPath returns the audit file location,
+// $XDG_STATE_HOME/flowtop/audit.jsonl (default ~/.local/state/flowtop/audit.jsonl)
+func DefaultPath() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "flowtop", "audit.jsonl"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "state", "flowtop", "audit.jsonl"), nil
+}
+
+// New returns a Log writing to path
+func New(path string) *Log {
+	return &Log{path: path}
+}
+
+// Path returns the file the log writes to
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append writes entries to the end of the file, creating it if needed
+func (l *Log) Append(entries ...Entry) error {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// Recent returns up to n of the latest entries, newest first.
+// Lines that cannot be parsed are skipped.
+func (l *Log) Recent(n int) ([]Entry, error) {
+	f, err := os.Open(l.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+		if len(entries) > n {
+			entries = entries[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecentMissingFile(t *testing.T) {
+	l := New(filepath.Join(t.TempDir(), "missing", "audit.jsonl"))
+	entries, err := l.Recent(10)
+	if err != nil || entries != nil {
+		t.Errorf("Recent() = %v, %v, want nil, nil", entries, err)
+	}
+}
+
+func TestAppendAndRecent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "flowtop", "audit.jsonl")
+	l := New(path)
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	entry := func(i int) Entry {
+		return Entry{
+			Time:   start.Add(time.Duration(i) * time.Minute),
+			Verb:   "delete",
+			Kind:   "Job",
+			Name:   string(rune('a' + i)),
+			Result: ResultSuccess,
+		}
+	}
+
+	// Appends across calls, creating the directory on first use
+	if err := l.Append(entry(0), entry(1)); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := l.Append(entry(2)); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("audit file mode = %v, %v, want 0600", info, err)
+	}
+
+	// A line that cannot be parsed is skipped
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString("not json\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	if err := New(path).Append(entry(3)); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	tests := []struct {
+		n    int
+		want []string
+	}{
+		{n: 10, want: []string{"d", "c", "b", "a"}},
+		{n: 2, want: []string{"d", "c"}},
+		{n: 0, want: []string{}},
+	}
+	for _, tt := range tests {
+		entries, err := l.Recent(tt.n)
+		if err != nil {
+			t.Fatalf("Recent(%d) error = %v", tt.n, err)
+		}
+		if len(entries) != len(tt.want) {
+			t.Fatalf("Recent(%d) returned %d entries, want %d", tt.n, len(entries), len(tt.want))
+		}
+		for i, e := range entries {
+			if e.Name != tt.want[i] {
+				t.Errorf("Recent(%d)[%d] = %s, want %s", tt.n, i, e.Name, tt.want[i])
+			}
+		}
+	}
+
+	entries, _ := l.Recent(1)
+	if got := entries[0]; !got.Time.Equal(entry(3).Time) || got.Verb != "delete" || got.Result != ResultSuccess {
+		t.Errorf("Recent(1)[0] = %+v, want %+v", got, entry(3))
+	}
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Config is the user configuration, read from
+// $XDG_CONFIG_HOME/flowtop/config.yaml (default ~/.config/flowtop/config.yaml)
+type Config struct {
+	// ReadOnly disables all write actions; set by --readonly
+	ReadOnly bool `json:"-"`
+
+	// ReadOnlyContexts lists kubeconfig context name patterns on which
+	// write actions are disabled
+	ReadOnlyContexts []string `json:"readOnlyContexts,omitempty"`
+
+	// ProductionContexts lists kubeconfig context name patterns (e.g. "*prod*")
+	// on which destructive actions require typing the namespace
+	ProductionContexts []string `json:"productionContexts,omitempty"`
+
+	// AuditLog is the JSONL file actions are recorded to
+	// (default $XDG_STATE_HOME/flowtop/audit.jsonl)
+	AuditLog string `json:"auditLog,omitempty"`
+
+	// Kinds maps in-house CRDs onto rows through JSONPath expressions
+	Kinds []KindMapping `json:"kinds,omitempty"`
+}
+
+// KindMapping declares a custom resource kind. Paths are JSONPath
+// expressions as in kubectl (e.g. "{.status.phase}"; the braces may be
+// omitted) and are all optional.
+type KindMapping struct {
+	Kind     string `json:"kind"`
+	Group    string `json:"group"`
+	Version  string `json:"version"`
+	Resource string `json:"resource"`
+
+	// ClusterScoped kinds are listed whatever the namespace
+	ClusterScoped bool `json:"clusterScoped,omitempty"`
+
+	// Tab is the tab listing the kind besides All: jobs, workflows or events
+	Tab string `json:"tab,omitempty"`
+
+	// Phase is mapped to a status through PhaseMap; unmapped phases that
+	// name a status (e.g. "Running") are used as is
+	Phase    string            `json:"phase,omitempty"`
+	PhaseMap map[string]string `json:"phaseMap,omitempty"`
+
+	StartTime      string `json:"startTime,omitempty"`
+	EndTime        string `json:"endTime,omitempty"`
+	LastRun        string `json:"lastRun,omitempty"`
+	Schedule       string `json:"schedule,omitempty"`
+	Timezone       string `json:"timezone,omitempty"`
+	Message        string `json:"message,omitempty"`
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// Parent is the name of the object the row is nested under in the
+	// tree, of kind ParentKind
+	Parent     string `json:"parent,omitempty"`
+	ParentKind string `json:"parentKind,omitempty"`
+}
+
+// Path returns the location of the config file
+func Path() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "flowtop", "config.yaml"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "flowtop", "config.yaml"), nil
+}
+
+// Load reads the config file; a missing file yields the zero Config
+func Load() (Config, error) {
+	var cfg Config
+
+	p, err := Path()
+	if err != nil {
+		return cfg, err
+	}
+	data, err := os.ReadFile(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("invalid config %s: %w", p, err)
+	}
+	return cfg, nil
+}
+
+// IsProduction reports whether a context matches a production pattern
+func (c Config) IsProduction(context string) bool {
+	return matchAny(c.ProductionContexts, context)
+}
+
+// ReadOnlyReason returns why write actions are disabled on a context,
+// or "" if they are allowed
+func (c Config) ReadOnlyReason(context string) string {
+	switch {
+	case c.ReadOnly:
+		return "--readonly"
+	case matchAny(c.ReadOnlyContexts, context):
+		return fmt.Sprintf("context %q matches readOnlyContexts", context)
+	}
+	return ""
+}
+
+// matchAny reports whether s matches one of the glob patterns. Unlike
+// path.Match, "*" also matches "/", which EKS context names contain.
+func matchAny(patterns []string, s string) bool {
+	for _, pattern := range patterns {
+		expr := regexp.QuoteMeta(pattern)
+		expr = strings.ReplaceAll(expr, `\*`, ".*")
+		expr = strings.ReplaceAll(expr, `\?`, ".")
+		if ok, _ := regexp.MatchString("^"+expr+"$", s); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package config
+
+import "testing"
+
+func TestMatchAny(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		s        string
+		want     bool
+	}{
+		{"no patterns", nil, "prod", false},
+		{"exact", []string{"prod"}, "prod", true},
+		{"prefix only", []string{"prod"}, "prod-eu", false},
+		{"star", []string{"prod-*"}, "prod-eu", true},
+		{"star crosses slashes", []string{"*prod*"}, "arn:aws:eks:us-east-1:123:cluster/prod", true},
+		{"question mark", []string{"prod-?"}, "prod-1", true},
+		{"question mark is one character", []string{"prod-?"}, "prod-12", false},
+		{"regexp characters are literal", []string{"prod.eu"}, "prod-eu", false},
+		{"any pattern", []string{"staging", "prod*"}, "production", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchAny(tt.patterns, tt.s); got != tt.want {
+				t.Errorf("matchAny(%q, %q) = %v, want %v", tt.patterns, tt.s, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package k8s
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ginbear/k8s-flowtop/internal/kinds"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+	authv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Verbs checked for listing and for actions
+var (
+	readVerbs  = []string{"list", "watch"}
+	writeVerbs = []string{"create", "update", "patch", "delete"}
+)
+
+// accessKinds lists the kinds permissions are checked for: the
+// registered kinds, Events, and Pods, which some actions delete
+func accessKinds() []types.ResourceKind {
+	var ks []types.ResourceKind
+	for _, k := range kinds.All() {
+		ks = append(ks, k.Kind)
+	}
+	return append(ks, types.KindEvent, types.KindPod)
+}
+
+// maxAccessReviews limits the access reviews run at once
+const maxAccessReviews = 8
+
+var (
+	eventGVR = schema.GroupVersionResource{Version: "v1", Resource: "events"}
+	podGVR   = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+)
+
+// accessGVR returns the resource checked for a kind
+func (c *Client) accessGVR(kind types.ResourceKind) (schema.GroupVersionResource, bool) {
+	switch kind {
+	case types.KindEvent:
+		return eventGVR, true
+	case types.KindPod:
+		return podGVR, true
+	}
+	return c.gvrForKind(kind)
+}
+
+// CheckReadAccess runs a SelfSubjectAccessReview for list and watch on
+// each kind in the watched namespace
+func (c *Client) CheckReadAccess(ctx context.Context) types.Access {
+	type review struct {
+		key       types.AccessKey
+		gvr       schema.GroupVersionResource
+		namespace string
+	}
+	var reviews []review
+	for _, kind := range accessKinds() {
+		gvr, _ := c.accessGVR(kind)
+		namespace := c.namespace
+		if k, ok := kinds.Lookup(kind); ok && k.ClusterScoped {
+			namespace = ""
+		}
+		for _, verb := range readVerbs {
+			reviews = append(reviews, review{types.AccessKey{Namespace: c.namespace, Kind: kind, Verb: verb}, gvr, namespace})
+		}
+	}
+
+	access := make(types.Access)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxAccessReviews)
+	for _, r := range reviews {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			ssar := &authv1.SelfSubjectAccessReview{
+				Spec: authv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: &authv1.ResourceAttributes{
+						Namespace: r.namespace,
+						Verb:      r.key.Verb,
+						Group:     r.gvr.Group,
+						Resource:  r.gvr.Resource,
+					},
+				},
+			}
+			result, err := c.clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, ssar, metav1.CreateOptions{})
+			if err != nil {
+				// Unknown; leave it allowed and let the API server decide
+				return
+			}
+			mu.Lock()
+			access[r.key] = result.Status.Allowed
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return access
+}
+
+// CheckWriteAccess runs a SelfSubjectRulesReview in each namespace and
+// records which write verbs it grants on each kind. Denials are only
+// recorded when the rules are complete.
+func (c *Client) CheckWriteAccess(ctx context.Context, namespaces []string) types.Access {
+	access := make(types.Access)
+	for _, ns := range namespaces {
+		ssrr := &authv1.SelfSubjectRulesReview{
+			Spec: authv1.SelfSubjectRulesReviewSpec{Namespace: ns},
+		}
+		result, err := c.clientset.AuthorizationV1().SelfSubjectRulesReviews().Create(ctx, ssrr, metav1.CreateOptions{})
+		if err != nil {
+			continue
+		}
+		for _, kind := range accessKinds() {
+			gvr, _ := c.accessGVR(kind)
+			for _, verb := range writeVerbs {
+				allowed := rulesAllow(result.Status.ResourceRules, gvr, verb)
+				if allowed || !result.Status.Incomplete {
+					access[types.AccessKey{Namespace: ns, Kind: kind, Verb: verb}] = allowed
+				}
+			}
+		}
+	}
+	return access
+}
+
+// rulesAllow reports whether a verb on a whole resource is granted by rules
+func rulesAllow(rules []authv1.ResourceRule, gvr schema.GroupVersionResource, verb string) bool {
+	for _, rule := range rules {
+		if len(rule.ResourceNames) > 0 {
+			continue
+		}
+		if matchRule(rule.APIGroups, gvr.Group) && matchRule(rule.Resources, gvr.Resource) && matchRule(rule.Verbs, verb) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRule reports whether a rule field lists value or the "*" wildcard
+func matchRule(values []string, value string) bool {
+	for _, v := range values {
+		if v == "*" || v == value {
+			return true
+		}
+	}
+	return false
+}
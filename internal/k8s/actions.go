@@ -0,0 +1,388 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ginbear/k8s-flowtop/internal/types"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// SetSuspend suspends or resumes the schedule of a CronJob or CronWorkflow
+func (c *Client) SetSuspend(ctx context.Context, r types.AsyncResource, suspend bool) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"suspend":%t}}`, suspend))
+
+	var err error
+	switch r.Kind {
+	case types.KindCronJob:
+		_, err = c.clientset.BatchV1().CronJobs(r.Namespace).Patch(ctx, r.Name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+	case types.KindCronWorkflow:
+		_, err = c.dynamicClient.Resource(c.gvr(types.KindCronWorkflow)).Namespace(r.Namespace).Patch(ctx, r.Name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+	default:
+		return fmt.Errorf("%s cannot be suspended", r.Kind)
+	}
+	return err
+}
+
+// Annotations and labels set on manually triggered runs
+const (
+	instantiateAnnotation = "cronjob.kubernetes.io/instantiate"
+	cronWorkflowLabel     = "workflows.argoproj.io/cron-workflow"
+)
+
+// maxNameLength is the longest name allowed for Jobs (it becomes a label value)
+const maxNameLength = 63
+
+// TriggerCronJob creates a Job from a CronJob's jobTemplate, like
+// `kubectl create job --from=cronjob/<name>`
+func (c *Client) TriggerCronJob(ctx context.Context, r types.AsyncResource) (types.AsyncResource, error) {
+	cj, err := c.clientset.BatchV1().CronJobs(r.Namespace).Get(ctx, r.Name, metav1.GetOptions{})
+	if err != nil {
+		return types.AsyncResource{}, err
+	}
+
+	annotations := map[string]string{instantiateAnnotation: "manual"}
+	for k, v := range cj.Spec.JobTemplate.Annotations {
+		annotations[k] = v
+	}
+
+	// The API server appends a 5 character random suffix
+	prefix := cj.Name
+	if len(prefix) > maxNameLength-len("-manual-")-5 {
+		prefix = prefix[:maxNameLength-len("-manual-")-5]
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: prefix + "-manual-",
+			Namespace:    cj.Namespace,
+			Labels:       cj.Spec.JobTemplate.Labels,
+			Annotations:  annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cj, batchv1.SchemeGroupVersion.WithKind("CronJob")),
+			},
+		},
+		Spec: cj.Spec.JobTemplate.Spec,
+	}
+
+	created, err := c.clientset.BatchV1().Jobs(cj.Namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return types.AsyncResource{}, err
+	}
+	return jobToResource(*created), nil
+}
+
+// CronWorkflowParameters returns the workflow arguments of a CronWorkflow
+func (c *Client) CronWorkflowParameters(ctx context.Context, r types.AsyncResource) ([]types.NodeIO, error) {
+	obj, err := c.dynamicClient.Resource(c.gvr(types.KindCronWorkflow)).Namespace(r.Namespace).Get(ctx, r.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	args, _, _ := unstructured.NestedMap(obj.Object, "spec", "workflowSpec", "arguments")
+	if args == nil {
+		return nil, nil
+	}
+	return nodeIO(map[string]interface{}{"parameters": args["parameters"]}), nil
+}
+
+// SubmitCronWorkflow submits a Workflow from a CronWorkflow's workflowSpec,
+// like `argo submit --from cronwf/<name>`, overriding the given parameters
+func (c *Client) SubmitCronWorkflow(ctx context.Context, r types.AsyncResource, params map[string]string) (types.AsyncResource, error) {
+	cw, err := c.dynamicClient.Resource(c.gvr(types.KindCronWorkflow)).Namespace(r.Namespace).Get(ctx, r.Name, metav1.GetOptions{})
+	if err != nil {
+		return types.AsyncResource{}, err
+	}
+	spec, ok, _ := unstructured.NestedMap(cw.Object, "spec", "workflowSpec")
+	if !ok {
+		return types.AsyncResource{}, fmt.Errorf("cronworkflow %s has no workflowSpec", r.Name)
+	}
+	setParameters(spec, params)
+
+	wf := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	wf.SetAPIVersion(c.gvr(types.KindWorkflow).GroupVersion().String())
+	wf.SetKind("Workflow")
+	wf.SetNamespace(cw.GetNamespace())
+	wf.SetGenerateName(cw.GetName() + "-")
+
+	// Same metadata the Argo controller puts on scheduled runs
+	labels := map[string]string{}
+	annotations := map[string]string{}
+	if meta, ok, _ := unstructured.NestedMap(cw.Object, "spec", "workflowMetadata"); ok {
+		if l, ok := meta["labels"].(map[string]interface{}); ok {
+			for k, v := range l {
+				labels[k] = fmt.Sprint(v)
+			}
+		}
+		if a, ok := meta["annotations"].(map[string]interface{}); ok {
+			for k, v := range a {
+				annotations[k] = fmt.Sprint(v)
+			}
+		}
+	}
+	labels[cronWorkflowLabel] = cw.GetName()
+	wf.SetLabels(labels)
+	if len(annotations) > 0 {
+		wf.SetAnnotations(annotations)
+	}
+	wf.SetOwnerReferences([]metav1.OwnerReference{
+		*metav1.NewControllerRef(cw, c.gvr(types.KindCronWorkflow).GroupVersion().WithKind("CronWorkflow")),
+	})
+
+	created, err := c.dynamicClient.Resource(c.gvr(types.KindWorkflow)).Namespace(cw.GetNamespace()).Create(ctx, wf, metav1.CreateOptions{})
+	if err != nil {
+		return types.AsyncResource{}, err
+	}
+	res := workflowToResource(*created)
+	res.Status = types.StatusPending
+	return res, nil
+}
+
+// setParameters overrides the values of declared spec.arguments.parameters
+func setParameters(spec map[string]interface{}, params map[string]string) {
+	list, _, _ := unstructured.NestedSlice(spec, "arguments", "parameters")
+	for _, p := range list {
+		if param, ok := p.(map[string]interface{}); ok {
+			name, _ := param["name"].(string)
+			if v, ok := params[name]; ok {
+				param["value"] = v
+			}
+		}
+	}
+	if len(list) > 0 {
+		_ = unstructured.SetNestedSlice(spec, list, "arguments", "parameters")
+	}
+}
+
+// Workflow labels maintained by the Argo controller
+const (
+	completedLabel   = "workflows.argoproj.io/completed"
+	phaseLabel       = "workflows.argoproj.io/phase"
+	resubmittedLabel = "workflows.argoproj.io/resubmitted-from-workflow"
+	shutdownField    = "shutdown"
+)
+
+// ShutdownWorkflow stops or terminates a Workflow by setting spec.shutdown,
+// like `argo stop` ("Stop", exit handlers run) and `argo terminate` ("Terminate")
+func (c *Client) ShutdownWorkflow(ctx context.Context, r types.AsyncResource, strategy string) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{%q:%q}}`, shutdownField, strategy))
+	_, err := c.dynamicClient.Resource(c.gvr(types.KindWorkflow)).Namespace(r.Namespace).Patch(ctx, r.Name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// ResubmitWorkflow submits a copy of a Workflow, like `argo resubmit`
+func (c *Client) ResubmitWorkflow(ctx context.Context, r types.AsyncResource) (types.AsyncResource, error) {
+	wf, err := c.dynamicClient.Resource(c.gvr(types.KindWorkflow)).Namespace(r.Namespace).Get(ctx, r.Name, metav1.GetOptions{})
+	if err != nil {
+		return types.AsyncResource{}, err
+	}
+	spec, _, _ := unstructured.NestedMap(wf.Object, "spec")
+	delete(spec, shutdownField)
+
+	next := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	next.SetAPIVersion(wf.GetAPIVersion())
+	next.SetKind(wf.GetKind())
+	next.SetNamespace(wf.GetNamespace())
+	if gen := wf.GetGenerateName(); gen != "" {
+		next.SetGenerateName(gen)
+	} else {
+		next.SetGenerateName(wf.GetName() + "-")
+	}
+
+	labels := wf.GetLabels()
+	delete(labels, completedLabel)
+	delete(labels, phaseLabel)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[resubmittedLabel] = wf.GetName()
+	next.SetLabels(labels)
+	next.SetAnnotations(wf.GetAnnotations())
+	next.SetOwnerReferences(wf.GetOwnerReferences())
+
+	created, err := c.dynamicClient.Resource(c.gvr(types.KindWorkflow)).Namespace(wf.GetNamespace()).Create(ctx, next, metav1.CreateOptions{})
+	if err != nil {
+		return types.AsyncResource{}, err
+	}
+	res := workflowToResource(*created)
+	res.Status = types.StatusPending
+	return res, nil
+}
+
+// RetryWorkflow re-runs the failed parts of a Workflow, like `argo retry`:
+// failed pod nodes are removed (and their pods deleted) so the controller
+// runs them again, and failed parent nodes are reset to Running. Pods are
+// deleted first, so the Workflow is left unchanged if that fails.
+func (c *Client) RetryWorkflow(ctx context.Context, r types.AsyncResource) error {
+	wf, err := c.dynamicClient.Resource(c.gvr(types.KindWorkflow)).Namespace(r.Namespace).Get(ctx, r.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	nodes, pods, err := retryNodes(wf)
+	if err != nil {
+		return err
+	}
+
+	// Old pods would otherwise block the new attempts from being created
+	for _, pod := range pods {
+		err := c.clientset.CoreV1().Pods(r.Namespace).Delete(ctx, pod, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("deleting pod %s failed, workflow left unchanged: %w", pod, err)
+		}
+	}
+
+	_ = unstructured.SetNestedMap(wf.Object, nodes, "status", "nodes")
+	_ = unstructured.SetNestedField(wf.Object, "Running", "status", "phase")
+	unstructured.RemoveNestedField(wf.Object, "status", "finishedAt")
+	unstructured.RemoveNestedField(wf.Object, "status", "message")
+	unstructured.RemoveNestedField(wf.Object, "spec", shutdownField)
+	labels := wf.GetLabels()
+	delete(labels, completedLabel)
+	if labels != nil {
+		labels[phaseLabel] = "Running"
+	}
+	wf.SetLabels(labels)
+
+	_, err = c.dynamicClient.Resource(c.gvr(types.KindWorkflow)).Namespace(r.Namespace).Update(ctx, wf, metav1.UpdateOptions{})
+	return err
+}
+
+// RetryPods returns the pods RetryWorkflow would delete
+func (c *Client) RetryPods(ctx context.Context, r types.AsyncResource) ([]string, error) {
+	wf, err := c.dynamicClient.Resource(c.gvr(types.KindWorkflow)).Namespace(r.Namespace).Get(ctx, r.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	_, pods, err := retryNodes(wf)
+	return pods, err
+}
+
+// retryNodes returns the node statuses of a failed Workflow reset for a
+// retry, and the pods of the failed pod nodes it drops
+func retryNodes(wf *unstructured.Unstructured) (map[string]interface{}, []string, error) {
+	phase, _, _ := unstructured.NestedString(wf.Object, "status", "phase")
+	if phase != "Failed" && phase != "Error" {
+		return nil, nil, fmt.Errorf("workflow %s is %s; only Failed or Error workflows can be retried", wf.GetName(), phase)
+	}
+	if v, ok, _ := unstructured.NestedString(wf.Object, "status", "offloadNodeStatusVersion"); ok && v != "" {
+		return nil, nil, fmt.Errorf("workflow %s has offloaded node status; use the argo CLI to retry it", wf.GetName())
+	}
+
+	podNameFormat := wf.GetAnnotations()[podNameFormatAnnotation]
+	nodes, _, _ := unstructured.NestedMap(wf.Object, "status", "nodes")
+	var pods []string
+	for id, n := range nodes {
+		node, ok := n.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if p, _ := node["phase"].(string); p != "Failed" && p != "Error" {
+			continue
+		}
+		if nodeType, _ := node["type"].(string); nodeType == "Pod" {
+			if podName := dagNodeFromStatus(id, node, wf.GetName(), podNameFormat).PodName; podName != "" {
+				pods = append(pods, podName)
+			}
+			delete(nodes, id)
+			continue
+		}
+		node["phase"] = "Running"
+		delete(node, "finishedAt")
+		delete(node, "message")
+	}
+	sort.Strings(pods)
+	return nodes, pods, nil
+}
+
+// Labels the Job controller adds to a Job and its pod template
+var jobControllerLabels = []string{
+	"controller-uid",
+	"job-name",
+	"batch.kubernetes.io/controller-uid",
+	"batch.kubernetes.io/job-name",
+}
+
+// Annotations set by controllers and clients on the original Job, which
+// do not describe a copy
+var jobControllerAnnotations = []string{
+	"batch.kubernetes.io/job-tracking",
+	"batch.kubernetes.io/cronjob-scheduled-timestamp",
+	"kubectl.kubernetes.io/last-applied-configuration",
+}
+
+// DeleteResource deletes a resource with the given propagation policy
+// ("Background", "Foreground" or "Orphan")
+func (c *Client) DeleteResource(ctx context.Context, r types.AsyncResource, propagation string) error {
+	gvr, ok := c.gvrForKind(r.Kind)
+	if !ok {
+		return fmt.Errorf("unsupported kind %s", r.Kind)
+	}
+	policy := metav1.DeletionPropagation(propagation)
+	return c.dynamicClient.Resource(gvr).Namespace(r.Namespace).Delete(ctx, r.Name, metav1.DeleteOptions{PropagationPolicy: &policy})
+}
+
+// RerunJob creates a copy of a finished Job. The controller-generated
+// selector and labels are dropped so the API server generates new ones.
+// The copy keeps its owners but none controls it, so a CronJob does not
+// count it against its history limits or prune it with them.
+func (c *Client) RerunJob(ctx context.Context, r types.AsyncResource) (types.AsyncResource, error) {
+	job, err := c.clientset.BatchV1().Jobs(r.Namespace).Get(ctx, r.Name, metav1.GetOptions{})
+	if err != nil {
+		return types.AsyncResource{}, err
+	}
+
+	prefix := job.Name
+	if len(prefix) > maxNameLength-len("-rerun-")-5 {
+		prefix = prefix[:maxNameLength-len("-rerun-")-5]
+	}
+
+	spec := *job.Spec.DeepCopy()
+	spec.Selector = nil
+	spec.ManualSelector = nil
+	for _, l := range jobControllerLabels {
+		delete(spec.Template.Labels, l)
+	}
+	labels := make(map[string]string)
+	for k, v := range job.Labels {
+		labels[k] = v
+	}
+	for _, l := range jobControllerLabels {
+		delete(labels, l)
+	}
+	annotations := make(map[string]string)
+	for k, v := range job.Annotations {
+		annotations[k] = v
+	}
+	for _, a := range jobControllerAnnotations {
+		delete(annotations, a)
+	}
+	var owners []metav1.OwnerReference
+	for _, ref := range job.OwnerReferences {
+		if ref.Kind == "CronJob" {
+			annotations[instantiateAnnotation] = "manual"
+		}
+		ref.Controller = nil
+		owners = append(owners, ref)
+	}
+
+	rerun := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName:    prefix + "-rerun-",
+			Namespace:       job.Namespace,
+			Labels:          labels,
+			Annotations:     annotations,
+			OwnerReferences: owners,
+		},
+		Spec: spec,
+	}
+
+	created, err := c.clientset.BatchV1().Jobs(job.Namespace).Create(ctx, rerun, metav1.CreateOptions{})
+	if err != nil {
+		return types.AsyncResource{}, err
+	}
+	return jobToResource(*created), nil
+}
@@ -0,0 +1,570 @@
+package k8s
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ginbear/k8s-flowtop/internal/types"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// actionClient returns a client backed by fake typed and dynamic clients
+func actionClient(typed []runtime.Object, dynamic ...runtime.Object) (*Client, *fake.Clientset, *dynamicfake.FakeDynamicClient) {
+	cs := fake.NewSimpleClientset(typed...)
+	dc := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), dynamic...)
+	return &Client{clientset: cs, dynamicClient: dc}, cs, dc
+}
+
+// argoObject returns an Argo object of a kind with the given fields
+func argoObject(kind types.ResourceKind, name string, fields map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: fields}
+	obj.SetAPIVersion("argoproj.io/v1alpha1")
+	obj.SetKind(string(kind))
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	obj.SetUID(k8stypes.UID("uid-" + name))
+	return obj
+}
+
+func TestSetSuspend(t *testing.T) {
+	cj := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"}}
+	cw := argoObject(types.KindCronWorkflow, "hourly", map[string]interface{}{
+		"spec": map[string]interface{}{"schedule": "0 * * * *", "suspend": false},
+	})
+	c, cs, dc := actionClient([]runtime.Object{cj}, cw)
+	ctx := context.Background()
+
+	for _, suspend := range []bool{true, false} {
+		if err := c.SetSuspend(ctx, types.AsyncResource{Kind: types.KindCronJob, Name: "nightly", Namespace: "default"}, suspend); err != nil {
+			t.Fatalf("SetSuspend(CronJob, %v) error = %v", suspend, err)
+		}
+		got, _ := cs.BatchV1().CronJobs("default").Get(ctx, "nightly", metav1.GetOptions{})
+		if got.Spec.Suspend == nil || *got.Spec.Suspend != suspend {
+			t.Errorf("CronJob spec.suspend = %v, want %v", got.Spec.Suspend, suspend)
+		}
+
+		if err := c.SetSuspend(ctx, types.AsyncResource{Kind: types.KindCronWorkflow, Name: "hourly", Namespace: "default"}, suspend); err != nil {
+			t.Fatalf("SetSuspend(CronWorkflow, %v) error = %v", suspend, err)
+		}
+		obj, _ := dc.Resource(c.gvr(types.KindCronWorkflow)).Namespace("default").Get(ctx, "hourly", metav1.GetOptions{})
+		if got, _, _ := unstructured.NestedBool(obj.Object, "spec", "suspend"); got != suspend {
+			t.Errorf("CronWorkflow spec.suspend = %v, want %v", got, suspend)
+		}
+		if schedule, _, _ := unstructured.NestedString(obj.Object, "spec", "schedule"); schedule != "0 * * * *" {
+			t.Errorf("CronWorkflow spec.schedule = %q, want it kept", schedule)
+		}
+	}
+
+	if err := c.SetSuspend(ctx, types.AsyncResource{Kind: types.KindJob, Name: "x"}, true); err == nil {
+		t.Error("SetSuspend(Job) succeeded, want an error")
+	}
+}
+
+// generateNames fills in the names of created objects from GenerateName,
+// as the API server would
+func generateNames(f interface {
+	PrependReactor(verb, resource string, reaction k8stesting.ReactionFunc)
+}) {
+	f.PrependReactor("create", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if obj, err := meta.Accessor(action.(k8stesting.CreateAction).GetObject()); err == nil && obj.GetName() == "" {
+			obj.SetName(obj.GetGenerateName() + "abcde")
+		}
+		return false, nil, nil
+	})
+}
+
+func TestTriggerCronJob(t *testing.T) {
+	cj := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default", UID: "cj-uid"},
+		Spec: batchv1.CronJobSpec{
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app": "backup"},
+					Annotations: map[string]string{"team": "infra"},
+				},
+				Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "backup", Image: "backup:1"}}},
+				}},
+			},
+		},
+	}
+	c, cs, _ := actionClient([]runtime.Object{cj})
+	generateNames(cs)
+
+	created, err := c.TriggerCronJob(context.Background(), types.AsyncResource{Kind: types.KindCronJob, Name: "nightly", Namespace: "default"})
+	if err != nil {
+		t.Fatalf("TriggerCronJob() error = %v", err)
+	}
+	job, err := cs.BatchV1().Jobs("default").Get(context.Background(), created.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("created Job not found: %v", err)
+	}
+
+	if job.GenerateName != "nightly-manual-" {
+		t.Errorf("GenerateName = %q, want %q", job.GenerateName, "nightly-manual-")
+	}
+	if job.Annotations[instantiateAnnotation] != "manual" || job.Annotations["team"] != "infra" {
+		t.Errorf("annotations = %v", job.Annotations)
+	}
+	if job.Labels["app"] != "backup" {
+		t.Errorf("labels = %v", job.Labels)
+	}
+	ref := metav1.GetControllerOf(job)
+	if ref == nil || ref.Kind != "CronJob" || ref.Name != "nightly" || ref.UID != "cj-uid" {
+		t.Errorf("controller = %+v, want CronJob nightly", ref)
+	}
+	if len(job.Spec.Template.Spec.Containers) != 1 || job.Spec.Template.Spec.Containers[0].Image != "backup:1" {
+		t.Errorf("pod template not copied: %+v", job.Spec.Template.Spec)
+	}
+}
+
+func TestTriggerCronJobLongName(t *testing.T) {
+	name := strings.Repeat("n", 60)
+	cj := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	c, cs, _ := actionClient([]runtime.Object{cj})
+	generateNames(cs)
+
+	created, err := c.TriggerCronJob(context.Background(), types.AsyncResource{Kind: types.KindCronJob, Name: name, Namespace: "default"})
+	if err != nil {
+		t.Fatalf("TriggerCronJob() error = %v", err)
+	}
+	// The generated suffix replaces "abcde" on a real API server
+	if len(created.Name) > maxNameLength {
+		t.Errorf("name %q is longer than %d characters", created.Name, maxNameLength)
+	}
+}
+
+func TestSubmitCronWorkflow(t *testing.T) {
+	cw := argoObject(types.KindCronWorkflow, "hourly", map[string]interface{}{
+		"spec": map[string]interface{}{
+			"schedule": "0 * * * *",
+			"workflowMetadata": map[string]interface{}{
+				"labels":      map[string]interface{}{"team": "data"},
+				"annotations": map[string]interface{}{"note": "scheduled"},
+			},
+			"workflowSpec": map[string]interface{}{
+				"entrypoint": "main",
+				"arguments": map[string]interface{}{
+					"parameters": []interface{}{
+						map[string]interface{}{"name": "date", "value": "today"},
+						map[string]interface{}{"name": "mode", "value": "full"},
+					},
+				},
+			},
+		},
+	})
+	c, _, dc := actionClient(nil, cw)
+	generateNames(dc)
+	ctx := context.Background()
+
+	r := types.AsyncResource{Kind: types.KindCronWorkflow, Name: "hourly", Namespace: "default"}
+	params, err := c.CronWorkflowParameters(ctx, r)
+	if err != nil {
+		t.Fatalf("CronWorkflowParameters() error = %v", err)
+	}
+	if len(params) != 2 || params[0].Name != "date" || params[0].Value != "today" {
+		t.Errorf("CronWorkflowParameters() = %+v", params)
+	}
+
+	created, err := c.SubmitCronWorkflow(ctx, r, map[string]string{"date": "2026-01-01", "unknown": "x"})
+	if err != nil {
+		t.Fatalf("SubmitCronWorkflow() error = %v", err)
+	}
+	if created.Status != types.StatusPending {
+		t.Errorf("Status = %s, want Pending", created.Status)
+	}
+	wf, err := dc.Resource(c.gvr(types.KindWorkflow)).Namespace("default").Get(ctx, created.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("created Workflow not found: %v", err)
+	}
+
+	if wf.GetGenerateName() != "hourly-" {
+		t.Errorf("GenerateName = %q, want %q", wf.GetGenerateName(), "hourly-")
+	}
+	if labels := wf.GetLabels(); labels[cronWorkflowLabel] != "hourly" || labels["team"] != "data" {
+		t.Errorf("labels = %v", labels)
+	}
+	if wf.GetAnnotations()["note"] != "scheduled" {
+		t.Errorf("annotations = %v", wf.GetAnnotations())
+	}
+	ref := metav1.GetControllerOfNoCopy(wf)
+	if ref == nil || ref.Kind != "CronWorkflow" || ref.Name != "hourly" || ref.UID != "uid-hourly" {
+		t.Errorf("controller = %+v, want CronWorkflow hourly", ref)
+	}
+
+	got, _, _ := unstructured.NestedSlice(wf.Object, "spec", "arguments", "parameters")
+	want := []interface{}{
+		map[string]interface{}{"name": "date", "value": "2026-01-01"},
+		map[string]interface{}{"name": "mode", "value": "full"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parameters = %v, want %v", got, want)
+	}
+	if entrypoint, _, _ := unstructured.NestedString(wf.Object, "spec", "entrypoint"); entrypoint != "main" {
+		t.Errorf("entrypoint = %q, want main", entrypoint)
+	}
+
+	// The CronWorkflow itself is left as is
+	orig, _ := dc.Resource(c.gvr(types.KindCronWorkflow)).Namespace("default").Get(ctx, "hourly", metav1.GetOptions{})
+	origParams, _, _ := unstructured.NestedSlice(orig.Object, "spec", "workflowSpec", "arguments", "parameters")
+	if v := origParams[0].(map[string]interface{})["value"]; v != "today" {
+		t.Errorf("CronWorkflow parameter changed to %v", v)
+	}
+}
+
+// failedWorkflow returns a Workflow in a phase with the given node statuses
+func failedWorkflow(phase string, nodes map[string]interface{}) *unstructured.Unstructured {
+	return argoObject(types.KindWorkflow, "wf", map[string]interface{}{
+		"spec": map[string]interface{}{"entrypoint": "main"},
+		"status": map[string]interface{}{
+			"phase":      phase,
+			"finishedAt": "2026-01-01T00:10:00Z",
+			"message":    "child failed",
+			"nodes":      nodes,
+		},
+	})
+}
+
+// testNodes is a Workflow whose DAG failed in one of its steps
+func testNodes() map[string]interface{} {
+	return map[string]interface{}{
+		"wf": map[string]interface{}{
+			"name": "wf", "type": "DAG", "phase": "Failed",
+			"finishedAt": "2026-01-01T00:10:00Z", "message": "child failed",
+		},
+		"wf-1": map[string]interface{}{
+			"name": "wf.build", "type": "Pod", "phase": "Succeeded", "templateName": "build",
+		},
+		"wf-2": map[string]interface{}{
+			"name": "wf.test", "type": "Pod", "phase": "Failed", "templateName": "test",
+		},
+		"wf-3": map[string]interface{}{
+			"name": "wf.lint", "type": "Pod", "phase": "Error", "templateName": "lint", "podName": "recorded-pod",
+		},
+	}
+}
+
+func TestRetryNodes(t *testing.T) {
+	tests := []struct {
+		name        string
+		wf          *unstructured.Unstructured
+		format      string
+		wantErr     string
+		wantPods    []string
+		wantRemoved []string
+	}{
+		{
+			name:    "running workflow",
+			wf:      failedWorkflow("Running", testNodes()),
+			wantErr: "only Failed or Error",
+		},
+		{
+			name:    "succeeded workflow",
+			wf:      failedWorkflow("Succeeded", testNodes()),
+			wantErr: "only Failed or Error",
+		},
+		{
+			name: "offloaded node status",
+			wf: func() *unstructured.Unstructured {
+				wf := failedWorkflow("Failed", nil)
+				_ = unstructured.SetNestedField(wf.Object, "fnv:123", "status", "offloadNodeStatusVersion")
+				return wf
+			}(),
+			wantErr: "offloaded",
+		},
+		{
+			name:        "pod names in v2 format",
+			wf:          failedWorkflow("Failed", testNodes()),
+			wantPods:    []string{argoPodName("wf", "wf.test", "test", "wf-2", ""), "recorded-pod"},
+			wantRemoved: []string{"wf-2", "wf-3"},
+		},
+		{
+			name:        "pod names in v1 format",
+			wf:          failedWorkflow("Error", testNodes()),
+			format:      "v1",
+			wantPods:    []string{"recorded-pod", "wf-2"},
+			wantRemoved: []string{"wf-2", "wf-3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.format != "" {
+				tt.wf.SetAnnotations(map[string]string{podNameFormatAnnotation: tt.format})
+			}
+			nodes, pods, err := retryNodes(tt.wf)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("retryNodes() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("retryNodes() error = %v", err)
+			}
+
+			sort.Strings(tt.wantPods)
+			if !reflect.DeepEqual(pods, tt.wantPods) {
+				t.Errorf("pods = %v, want %v", pods, tt.wantPods)
+			}
+			for _, id := range tt.wantRemoved {
+				if _, ok := nodes[id]; ok {
+					t.Errorf("failed pod node %s was kept", id)
+				}
+			}
+
+			// Failed parents run again, with the failure cleared
+			parent := nodes["wf"].(map[string]interface{})
+			if parent["phase"] != "Running" {
+				t.Errorf("parent phase = %v, want Running", parent["phase"])
+			}
+			if _, ok := parent["finishedAt"]; ok {
+				t.Error("parent finishedAt was kept")
+			}
+			if _, ok := parent["message"]; ok {
+				t.Error("parent message was kept")
+			}
+
+			// Succeeded nodes are left alone
+			if !reflect.DeepEqual(nodes["wf-1"], testNodes()["wf-1"]) {
+				t.Errorf("succeeded node = %v, want it unchanged", nodes["wf-1"])
+			}
+		})
+	}
+}
+
+func TestRetryWorkflow(t *testing.T) {
+	podA := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "recorded-pod", Namespace: "default"}}
+	c, cs, dc := actionClient([]runtime.Object{podA}, failedWorkflow("Failed", testNodes()))
+
+	var calls []string
+	record := func(action k8stesting.Action) (bool, runtime.Object, error) {
+		calls = append(calls, action.GetVerb()+" "+action.GetResource().Resource)
+		return false, nil, nil
+	}
+	cs.PrependReactor("delete", "pods", record)
+	dc.PrependReactor("update", "workflows", record)
+
+	r := types.AsyncResource{Kind: types.KindWorkflow, Name: "wf", Namespace: "default"}
+	if err := c.RetryWorkflow(context.Background(), r); err != nil {
+		t.Fatalf("RetryWorkflow() error = %v", err)
+	}
+
+	// The derived pod of wf-2 is already gone, which is not an error
+	want := []string{"delete pods", "delete pods", "update workflows"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if _, err := cs.CoreV1().Pods("default").Get(context.Background(), "recorded-pod", metav1.GetOptions{}); err == nil {
+		t.Error("pod of the failed node was not deleted")
+	}
+
+	wf, _ := dc.Resource(c.gvr(types.KindWorkflow)).Namespace("default").Get(context.Background(), "wf", metav1.GetOptions{})
+	if phase, _, _ := unstructured.NestedString(wf.Object, "status", "phase"); phase != "Running" {
+		t.Errorf("phase = %q, want Running", phase)
+	}
+	if _, ok, _ := unstructured.NestedString(wf.Object, "status", "finishedAt"); ok {
+		t.Error("finishedAt was kept")
+	}
+	if nodes, _, _ := unstructured.NestedMap(wf.Object, "status", "nodes"); len(nodes) != 2 {
+		t.Errorf("nodes = %v, want the DAG and the succeeded step", nodes)
+	}
+}
+
+func TestRetryWorkflowPodDeleteFails(t *testing.T) {
+	c, cs, dc := actionClient(nil, failedWorkflow("Failed", testNodes()))
+	cs.PrependReactor("delete", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(corev1.Resource("pods"), "recorded-pod", nil)
+	})
+	dc.PrependReactor("update", "workflows", func(k8stesting.Action) (bool, runtime.Object, error) {
+		t.Error("workflow updated although a pod could not be deleted")
+		return false, nil, nil
+	})
+
+	err := c.RetryWorkflow(context.Background(), types.AsyncResource{Kind: types.KindWorkflow, Name: "wf", Namespace: "default"})
+	if err == nil || !strings.Contains(err.Error(), "workflow left unchanged") {
+		t.Errorf("RetryWorkflow() error = %v, want a pod delete error", err)
+	}
+}
+
+func TestShutdownWorkflow(t *testing.T) {
+	for _, strategy := range []string{"Stop", "Terminate"} {
+		t.Run(strategy, func(t *testing.T) {
+			c, _, dc := actionClient(nil, argoObject(types.KindWorkflow, "wf", map[string]interface{}{
+				"spec": map[string]interface{}{"entrypoint": "main"},
+			}))
+			if err := c.ShutdownWorkflow(context.Background(), types.AsyncResource{Kind: types.KindWorkflow, Name: "wf", Namespace: "default"}, strategy); err != nil {
+				t.Fatalf("ShutdownWorkflow() error = %v", err)
+			}
+
+			var patch k8stesting.PatchAction
+			for _, a := range dc.Actions() {
+				if p, ok := a.(k8stesting.PatchAction); ok {
+					patch = p
+				}
+			}
+			if patch == nil || patch.GetPatchType() != k8stypes.MergePatchType {
+				t.Fatalf("no merge patch recorded: %v", dc.Actions())
+			}
+			if want := `{"spec":{"shutdown":"` + strategy + `"}}`; string(patch.GetPatch()) != want {
+				t.Errorf("patch = %s, want %s", patch.GetPatch(), want)
+			}
+			wf, _ := dc.Resource(c.gvr(types.KindWorkflow)).Namespace("default").Get(context.Background(), "wf", metav1.GetOptions{})
+			if got, _, _ := unstructured.NestedString(wf.Object, "spec", "shutdown"); got != strategy {
+				t.Errorf("spec.shutdown = %q, want %q", got, strategy)
+			}
+		})
+	}
+}
+
+func TestResubmitWorkflow(t *testing.T) {
+	wf := failedWorkflow("Failed", testNodes())
+	wf.SetGenerateName("build-")
+	wf.SetLabels(map[string]string{completedLabel: "true", phaseLabel: "Failed", "team": "data"})
+	_ = unstructured.SetNestedField(wf.Object, "Terminate", "spec", shutdownField)
+	c, _, dc := actionClient(nil, wf)
+	generateNames(dc)
+
+	created, err := c.ResubmitWorkflow(context.Background(), types.AsyncResource{Kind: types.KindWorkflow, Name: "wf", Namespace: "default"})
+	if err != nil {
+		t.Fatalf("ResubmitWorkflow() error = %v", err)
+	}
+	next, err := dc.Resource(c.gvr(types.KindWorkflow)).Namespace("default").Get(context.Background(), created.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("resubmitted Workflow not found: %v", err)
+	}
+	if next.GetGenerateName() != "build-" {
+		t.Errorf("GenerateName = %q, want build-", next.GetGenerateName())
+	}
+	want := map[string]string{resubmittedLabel: "wf", "team": "data"}
+	if !reflect.DeepEqual(next.GetLabels(), want) {
+		t.Errorf("labels = %v, want %v", next.GetLabels(), want)
+	}
+	if _, ok, _ := unstructured.NestedString(next.Object, "spec", shutdownField); ok {
+		t.Error("spec.shutdown was copied")
+	}
+	if _, ok := next.Object["status"]; ok {
+		t.Error("status was copied")
+	}
+}
+
+func TestRerunJob(t *testing.T) {
+	controller := true
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nightly-28000000",
+			Namespace: "default",
+			Labels: map[string]string{
+				"app":                                "backup",
+				"controller-uid":                     "uid-1",
+				"job-name":                           "nightly-28000000",
+				"batch.kubernetes.io/controller-uid": "uid-1",
+				"batch.kubernetes.io/job-name":       "nightly-28000000",
+			},
+			Annotations: map[string]string{
+				"team":                             "infra",
+				"batch.kubernetes.io/job-tracking": "",
+				"batch.kubernetes.io/cronjob-scheduled-timestamp":  "2026-01-01T00:00:00Z",
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "batch/v1", Kind: "CronJob", Name: "nightly", UID: "cj-uid", Controller: &controller},
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"batch.kubernetes.io/controller-uid": "uid-1"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+					"app":                                "backup",
+					"controller-uid":                     "uid-1",
+					"job-name":                           "nightly-28000000",
+					"batch.kubernetes.io/controller-uid": "uid-1",
+					"batch.kubernetes.io/job-name":       "nightly-28000000",
+				}},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "backup", Image: "backup:1"}}},
+			},
+		},
+	}
+	c, cs, _ := actionClient([]runtime.Object{job})
+	generateNames(cs)
+
+	created, err := c.RerunJob(context.Background(), types.AsyncResource{Kind: types.KindJob, Name: job.Name, Namespace: "default"})
+	if err != nil {
+		t.Fatalf("RerunJob() error = %v", err)
+	}
+	rerun, err := cs.BatchV1().Jobs("default").Get(context.Background(), created.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("re-run Job not found: %v", err)
+	}
+
+	if rerun.GenerateName != "nightly-28000000-rerun-" {
+		t.Errorf("GenerateName = %q", rerun.GenerateName)
+	}
+	if rerun.Spec.Selector != nil || rerun.Spec.ManualSelector != nil {
+		t.Errorf("selector was copied: %v", rerun.Spec.Selector)
+	}
+	want := map[string]string{"app": "backup"}
+	if !reflect.DeepEqual(rerun.Labels, want) {
+		t.Errorf("labels = %v, want %v", rerun.Labels, want)
+	}
+	if !reflect.DeepEqual(rerun.Spec.Template.Labels, want) {
+		t.Errorf("pod template labels = %v, want %v", rerun.Spec.Template.Labels, want)
+	}
+	wantAnnotations := map[string]string{"team": "infra", instantiateAnnotation: "manual"}
+	if !reflect.DeepEqual(rerun.Annotations, wantAnnotations) {
+		t.Errorf("annotations = %v, want %v", rerun.Annotations, wantAnnotations)
+	}
+	if len(rerun.OwnerReferences) != 1 || rerun.OwnerReferences[0].UID != "cj-uid" {
+		t.Fatalf("owner references = %+v, want the CronJob", rerun.OwnerReferences)
+	}
+	if rerun.OwnerReferences[0].Controller != nil {
+		t.Error("the CronJob still controls the re-run")
+	}
+
+	// The original Job is left as is
+	orig, _ := cs.BatchV1().Jobs("default").Get(context.Background(), job.Name, metav1.GetOptions{})
+	if orig.Spec.Selector == nil || !*orig.OwnerReferences[0].Controller {
+		t.Error("the original Job was changed")
+	}
+}
+
+func TestDeleteResource(t *testing.T) {
+	for _, propagation := range []string{"Background", "Foreground", "Orphan"} {
+		t.Run(propagation, func(t *testing.T) {
+			c, _, dc := actionClient(nil, argoObject(types.KindWorkflow, "wf", map[string]interface{}{}))
+			if err := c.DeleteResource(context.Background(), types.AsyncResource{Kind: types.KindWorkflow, Name: "wf", Namespace: "default"}, propagation); err != nil {
+				t.Fatalf("DeleteResource() error = %v", err)
+			}
+
+			var del k8stesting.DeleteAction
+			for _, a := range dc.Actions() {
+				if d, ok := a.(k8stesting.DeleteAction); ok {
+					del = d
+				}
+			}
+			if del == nil || del.GetName() != "wf" || del.GetNamespace() != "default" {
+				t.Fatalf("no delete recorded: %v", dc.Actions())
+			}
+			if p := del.GetDeleteOptions().PropagationPolicy; p == nil || string(*p) != propagation {
+				t.Errorf("PropagationPolicy = %v, want %s", p, propagation)
+			}
+		})
+	}
+
+	c, _, _ := actionClient(nil)
+	if err := c.DeleteResource(context.Background(), types.AsyncResource{Kind: "Unknown", Name: "x"}, "Background"); err == nil {
+		t.Error("DeleteResource() of an unknown kind succeeded, want an error")
+	}
+}
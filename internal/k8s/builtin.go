@@ -0,0 +1,156 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ginbear/k8s-flowtop/internal/kinds"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Built-in kinds: Jobs, Argo Workflows and Argo Events
+func init() {
+	kinds.Register(
+		kinds.Kind{
+			Kind:    types.KindJob,
+			GVR:     jobGVR,
+			View:    types.ViewJobs,
+			Convert: convertTyped(jobToResource),
+			Details: kueueDetails,
+		},
+		kinds.Kind{
+			Kind:    types.KindCronJob,
+			GVR:     cronJobGVR,
+			View:    types.ViewJobs,
+			Convert: convertTyped(cronJobToResource),
+		},
+		kinds.Kind{
+			Kind:     types.KindWorkflow,
+			GVR:      workflowGVR,
+			View:     types.ViewWorkflows,
+			Convert:  convertUnstructured(workflowToResource),
+			PodLabel: workflowLabel,
+		},
+		kinds.Kind{
+			Kind:    types.KindCronWorkflow,
+			GVR:     cronWorkflowGVR,
+			View:    types.ViewWorkflows,
+			Convert: convertUnstructured(cronWorkflowToResource),
+		},
+		kinds.Kind{
+			Kind:     types.KindSensor,
+			GVR:      sensorGVR,
+			View:     types.ViewEvents,
+			Convert:  convertUnstructured(sensorToResource),
+			PodLabel: sensorNameLabel,
+			Columns: []kinds.Column{
+				{Header: "EVENT_SOURCE", Width: 20, Value: func(r types.AsyncResource) string { return r.EventSourceName }},
+				{Header: "EVENT_NAME", Width: 35, Value: func(r types.AsyncResource) string { return firstWithCount(r.EventNames) }},
+				{Header: "TRIGGER", Width: 35, Value: func(r types.AsyncResource) string { return firstWithCount(r.TriggerNames) }},
+			},
+			Details: eventDetails,
+		},
+		kinds.Kind{
+			Kind:     types.KindEventSource,
+			GVR:      eventSourceGVR,
+			View:     types.ViewEvents,
+			Convert:  convertUnstructured(eventSourceToResource),
+			PodLabel: eventSourceNameLabel,
+			Columns: []kinds.Column{
+				// EventSources show their event type in place of event names
+				{Header: "EVENT_NAME", Width: 35, Value: func(r types.AsyncResource) string { return r.EventType }},
+			},
+			Details: eventDetails,
+		},
+	)
+}
+
+// convertTyped adapts a converter of a typed object to listed objects
+func convertTyped[T any](convert func(T) types.AsyncResource) func(unstructured.Unstructured) (types.AsyncResource, error) {
+	return func(obj unstructured.Unstructured) (types.AsyncResource, error) {
+		var typed T
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &typed); err != nil {
+			return types.AsyncResource{}, fmt.Errorf("failed to convert %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		return convert(typed), nil
+	}
+}
+
+// convertUnstructured adapts a converter that cannot fail
+func convertUnstructured(convert func(unstructured.Unstructured) types.AsyncResource) func(unstructured.Unstructured) (types.AsyncResource, error) {
+	return func(obj unstructured.Unstructured) (types.AsyncResource, error) {
+		return convert(obj), nil
+	}
+}
+
+// firstWithCount shows the first value and how many others there are
+func firstWithCount(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	if len(values) > 1 {
+		return fmt.Sprintf("%s (+%d)", values[0], len(values)-1)
+	}
+	return values[0]
+}
+
+// eventDetails lists the Argo Events fields of a Sensor or EventSource
+func eventDetails(r types.AsyncResource) []kinds.Section {
+	var fields []kinds.Field
+	if r.EventSourceName != "" {
+		fields = append(fields, kinds.Field{Label: "EventSource", Value: r.EventSourceName})
+	}
+	if len(r.EventNames) > 0 {
+		fields = append(fields, kinds.Field{Label: "Events", Value: strings.Join(r.EventNames, ", ")})
+	}
+	if r.EventType != "" {
+		fields = append(fields, kinds.Field{Label: "Event Type", Value: r.EventType})
+	}
+	if len(r.TriggerNames) > 0 {
+		fields = append(fields, kinds.Field{Label: "Triggers", Value: strings.Join(r.TriggerNames, ", ")})
+	}
+	return []kinds.Section{{Fields: fields}}
+}
+
+// attribute is a row attribute shown in the detail overview
+type attribute struct {
+	key   string
+	label string
+}
+
+// setAttribute sets a row attribute, keeping the others
+func setAttribute(r *types.AsyncResource, key, value string) {
+	if r.Attributes == nil {
+		r.Attributes = make(map[string]string)
+	}
+	r.Attributes[key] = value
+}
+
+// attributeDetails returns Details listing the attributes a row has
+func attributeDetails(attrs ...attribute) func(types.AsyncResource) []kinds.Section {
+	return func(r types.AsyncResource) []kinds.Section {
+		var fields []kinds.Field
+		for _, a := range attrs {
+			if v := r.Attributes[a.key]; v != "" {
+				fields = append(fields, kinds.Field{Label: a.label, Value: v})
+			}
+		}
+		return []kinds.Section{{Fields: fields}}
+	}
+}
+
+// nestedTime parses an RFC 3339 timestamp field, if set
+func nestedTime(obj map[string]interface{}, fields ...string) *time.Time {
+	s, ok, _ := unstructured.NestedString(obj, fields...)
+	if !ok {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
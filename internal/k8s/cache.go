@@ -0,0 +1,92 @@
+package k8s
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// readCache holds values read from optional APIs in the background, so a
+// slow or missing API does not hold up the refresh. Missing and stale
+// values are read again; they show up on a later refresh.
+type readCache[V any] struct {
+	maxAge  time.Duration
+	timeout time.Duration
+
+	mu      sync.Mutex
+	values  map[string]cachedRead[V]
+	reading map[string]bool
+	sem     chan struct{}
+	running sync.WaitGroup
+}
+
+// cachedRead is a value as last read
+type cachedRead[V any] struct {
+	value V
+	ok    bool // a value has been read at least once
+	read  time.Time
+}
+
+// newReadCache returns a cache re-reading values older than maxAge, running
+// up to limit reads at once, each within timeout
+func newReadCache[V any](maxAge, timeout time.Duration, limit int) *readCache[V] {
+	return &readCache[V]{
+		maxAge:  maxAge,
+		timeout: timeout,
+		values:  make(map[string]cachedRead[V]),
+		reading: make(map[string]bool),
+		sem:     make(chan struct{}, limit),
+	}
+}
+
+// get returns the cached value of a key, and starts reading it in the
+// background if it is missing or stale and not being read already. A
+// failed read keeps the previous value.
+func (c *readCache[V]) get(key string, read func(ctx context.Context) (V, error)) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v := c.values[key]
+	if time.Since(v.read) > c.maxAge && !c.reading[key] {
+		c.reading[key] = true
+		c.running.Add(1)
+		go c.read(key, read)
+	}
+	return v.value, v.ok
+}
+
+func (c *readCache[V]) read(key string, read func(ctx context.Context) (V, error)) {
+	defer c.running.Done()
+	c.sem <- struct{}{}
+	defer func() { <-c.sem }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	value, err := read(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.reading, key)
+	v := c.values[key]
+	v.read = time.Now()
+	if err == nil {
+		v.value, v.ok = value, true
+	}
+	c.values[key] = v
+}
+
+// prune forgets the values of keys not in keep. Keys being read are kept
+// until the read is stored, and pruned on a later call.
+func (c *readCache[V]) prune(keep map[string]bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.values {
+		if !keep[key] && !c.reading[key] {
+			delete(c.values, key)
+		}
+	}
+}
+
+// wait blocks until the running reads are stored
+func (c *readCache[V]) wait() {
+	c.running.Wait()
+}
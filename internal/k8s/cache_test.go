@@ -0,0 +1,118 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReadCache(t *testing.T) {
+	c := newReadCache[int](time.Hour, time.Second, 2)
+	var reads atomic.Int32
+	value := 1
+	read := func(context.Context) (int, error) {
+		reads.Add(1)
+		return value, nil
+	}
+
+	// Missing values are read in the background
+	if _, ok := c.get("a", read); ok {
+		t.Error("get() returned a value before it was read")
+	}
+	c.wait()
+	if v, ok := c.get("a", read); !ok || v != 1 {
+		t.Errorf("get() = %d, %v, want 1, true", v, ok)
+	}
+	c.wait()
+	if n := reads.Load(); n != 1 {
+		t.Errorf("fresh value read %d times, want 1", n)
+	}
+
+	// Stale values are returned while they are read again
+	c.maxAge = 0
+	value = 2
+	if v, _ := c.get("a", read); v != 1 {
+		t.Errorf("get() = %d while re-reading, want the previous value", v)
+	}
+	c.wait()
+	if v, _ := c.get("a", read); v != 2 {
+		t.Errorf("get() = %d, want the new value", v)
+	}
+	c.wait()
+
+	// A failed read keeps the previous value
+	failing := func(context.Context) (int, error) { return 0, errors.New("unavailable") }
+	c.get("a", failing)
+	c.wait()
+	if v, ok := c.get("a", failing); !ok || v != 2 {
+		t.Errorf("get() = %d, %v after a failed read, want 2, true", v, ok)
+	}
+	c.wait()
+}
+
+func TestReadCacheTimeout(t *testing.T) {
+	c := newReadCache[int](time.Hour, 10*time.Millisecond, 1)
+	c.get("slow", func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	done := make(chan struct{})
+	go func() {
+		c.wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("read was not cancelled at its timeout")
+	}
+	if _, ok := c.get("slow", nil); ok {
+		t.Error("timed out read stored a value")
+	}
+}
+
+func TestReadCacheOneReadPerKey(t *testing.T) {
+	c := newReadCache[int](0, time.Second, 4)
+	release := make(chan struct{})
+	var reads atomic.Int32
+	read := func(context.Context) (int, error) {
+		reads.Add(1)
+		<-release
+		return 1, nil
+	}
+	for range 5 {
+		c.get("a", read)
+	}
+	close(release)
+	c.wait()
+	if n := reads.Load(); n != 1 {
+		t.Errorf("key read %d times at once, want 1", n)
+	}
+}
+
+func TestReadCachePrune(t *testing.T) {
+	c := newReadCache[int](0, time.Second, 1)
+	c.values["gone"] = cachedRead[int]{value: 1, ok: true}
+	c.values["kept"] = cachedRead[int]{value: 1, ok: true, read: time.Now().Add(time.Hour)}
+
+	// A stale key is being read when its object disappears
+	release := make(chan struct{})
+	c.get("gone", func(context.Context) (int, error) {
+		<-release
+		return 2, nil
+	})
+	c.prune(map[string]bool{"kept": true})
+	close(release)
+	c.wait()
+
+	// The read is stored, then pruned for good
+	c.prune(map[string]bool{"kept": true})
+	if _, ok := c.values["gone"]; ok {
+		t.Error("value of a pruned key came back")
+	}
+	if _, ok := c.values["kept"]; !ok {
+		t.Error("kept key was pruned")
+	}
+}
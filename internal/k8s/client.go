@@ -5,29 +5,54 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/ginbear/k8s-flowtop/internal/kinds"
 	"github.com/ginbear/k8s-flowtop/internal/types"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
 // Client wraps kubernetes clients
 type Client struct {
-	clientset     *kubernetes.Clientset
+	clientset     kubernetes.Interface
 	dynamicClient dynamic.Interface
 	namespace     string
 	context       string
 	cluster       string
+	user          string
+	contexts      []string // all contexts in the kubeconfig
+	config        *rest.Config
+	server        string
+
+	apisMu sync.RWMutex
+	apis   Discovery // async kinds served by the cluster, once discovered
+
+	metrics   *readCache[int64]                    // KEDA external metrics, by namespace/scaledObject/metric
+	positions *readCache[map[string]queuePosition] // Kueue pending workloads, by namespace/localQueue
 }
 
-// NewClient creates a new kubernetes client
+// NewClient creates a new kubernetes client for the current context
 func NewClient(namespace string) (*Client, error) {
+	return NewClientForContext(namespace, "")
+}
+
+// NewClientForContext creates a new kubernetes client for a kubeconfig
+// context ("" for the current one). All kinds are assumed installed until
+// Discover is run.
+func NewClientForContext(namespace, contextName string) (*Client, error) {
 	kubeconfig := os.Getenv("KUBECONFIG")
 	if kubeconfig == "" {
 		home, _ := os.UserHomeDir()
@@ -36,7 +61,7 @@ func NewClient(namespace string) (*Client, error) {
 
 	// Load kubeconfig to get context and cluster info
 	loadingRules := &clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig}
-	configOverrides := &clientcmd.ConfigOverrides{}
+	configOverrides := &clientcmd.ConfigOverrides{CurrentContext: contextName}
 	kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
 
 	rawConfig, err := kubeConfig.RawConfig()
@@ -45,12 +70,25 @@ func NewClient(namespace string) (*Client, error) {
 	}
 
 	currentContext := rawConfig.CurrentContext
-	var clusterName string
+	if contextName != "" {
+		currentContext = contextName
+	}
+	var clusterName, userName, server string
 	if ctx, ok := rawConfig.Contexts[currentContext]; ok {
 		clusterName = ctx.Cluster
+		userName = ctx.AuthInfo
+	}
+	if cluster, ok := rawConfig.Clusters[clusterName]; ok {
+		server = cluster.Server
 	}
 
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	var contexts []string
+	for name := range rawConfig.Contexts {
+		contexts = append(contexts, name)
+	}
+	sort.Strings(contexts)
+
+	config, err := kubeConfig.ClientConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build config: %w", err)
 	}
@@ -65,13 +103,20 @@ func NewClient(namespace string) (*Client, error) {
 		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
 	}
 
-	return &Client{
+	c := &Client{
 		clientset:     clientset,
 		dynamicClient: dynamicClient,
 		namespace:     namespace,
 		context:       currentContext,
 		cluster:       clusterName,
-	}, nil
+		user:          userName,
+		contexts:      contexts,
+		config:        config,
+		server:        server,
+		metrics:       newReadCache[int64](metricMaxAge, metricTimeout, maxMetricReads),
+		positions:     newReadCache[map[string]queuePosition](positionMaxAge, positionTimeout, maxPositionReads),
+	}
+	return c, nil
 }
 
 // GetNamespace returns the current namespace
@@ -84,49 +129,41 @@ func (c *Client) GetContext() string {
 	return c.context
 }
 
+// GetUser returns the kubeconfig user of the current context
+func (c *Client) GetUser() string {
+	return c.user
+}
+
 // GetCluster returns the current cluster name
 func (c *Client) GetCluster() string {
 	return c.cluster
 }
 
+// Contexts returns the context names in the kubeconfig
+func (c *Client) Contexts() []string {
+	return c.contexts
+}
+
 // SetNamespace sets the namespace to watch
 func (c *Client) SetNamespace(ns string) {
 	c.namespace = ns
 }
 
-// ListJobs returns all jobs in the namespace
-func (c *Client) ListJobs(ctx context.Context) ([]types.AsyncResource, error) {
-	var resources []types.AsyncResource
-
-	jobs, err := c.clientset.BatchV1().Jobs(c.namespace).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, err
-	}
-
-	for _, job := range jobs.Items {
-		resources = append(resources, jobToResource(job))
-	}
-
-	return resources, nil
-}
-
-// ListCronJobs returns all cronjobs in the namespace
-func (c *Client) ListCronJobs(ctx context.Context) ([]types.AsyncResource, error) {
-	var resources []types.AsyncResource
-
-	cronJobs, err := c.clientset.BatchV1().CronJobs(c.namespace).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, err
+// Core batch GVRs (used for raw object access)
+var (
+	jobGVR = schema.GroupVersionResource{
+		Group:    "batch",
+		Version:  "v1",
+		Resource: "jobs",
 	}
-
-	for _, cj := range cronJobs.Items {
-		resources = append(resources, cronJobToResource(cj))
+	cronJobGVR = schema.GroupVersionResource{
+		Group:    "batch",
+		Version:  "v1",
+		Resource: "cronjobs",
 	}
+)
 
-	return resources, nil
-}
-
-// Argo Workflows GVR
+// Argo GVRs, used unless discovery finds another served version
 var (
 	workflowGVR = schema.GroupVersionResource{
 		Group:    "argoproj.io",
@@ -150,100 +187,136 @@ var (
 	}
 )
 
-// ListWorkflows returns all Argo Workflows
-func (c *Client) ListWorkflows(ctx context.Context) ([]types.AsyncResource, error) {
-	var resources []types.AsyncResource
-
-	list, err := c.dynamicClient.Resource(workflowGVR).Namespace(c.namespace).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		// Argo Workflows might not be installed
-		return resources, nil
-	}
-
-	for _, item := range list.Items {
-		resources = append(resources, workflowToResource(item))
+// gvrForKind returns the resource a kind is read from, at the version
+// discovered on the cluster
+func (c *Client) gvrForKind(kind types.ResourceKind) (schema.GroupVersionResource, bool) {
+	if gvr, ok := c.APIs().Kinds[kind]; ok {
+		return gvr, true
 	}
+	return defaultGVR(kind)
+}
 
-	return resources, nil
+// gvr returns the resource of a known kind
+func (c *Client) gvr(kind types.ResourceKind) schema.GroupVersionResource {
+	gvr, _ := c.gvrForKind(kind)
+	return gvr
 }
 
-// ListCronWorkflows returns all Argo CronWorkflows
-func (c *Client) ListCronWorkflows(ctx context.Context) ([]types.AsyncResource, error) {
-	var resources []types.AsyncResource
+// defaultGVR returns the resource a kind is read from when discovery
+// did not find it
+func defaultGVR(kind types.ResourceKind) (schema.GroupVersionResource, bool) {
+	k, ok := kinds.Lookup(kind)
+	return k.GVR, ok
+}
 
-	list, err := c.dynamicClient.Resource(cronWorkflowGVR).Namespace(c.namespace).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return resources, nil
+// GetObject returns the full underlying object of a resource, without managedFields
+func (c *Client) GetObject(ctx context.Context, r types.AsyncResource) (map[string]interface{}, error) {
+	gvr, ok := c.gvrForKind(r.Kind)
+	if !ok {
+		return nil, fmt.Errorf("unsupported kind: %s", r.Kind)
 	}
 
-	for _, item := range list.Items {
-		resources = append(resources, cronWorkflowToResource(item))
+	obj, err := c.dynamicClient.Resource(gvr).Namespace(r.Namespace).Get(ctx, r.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
 	}
 
-	return resources, nil
+	obj.SetManagedFields(nil)
+	return obj.Object, nil
 }
 
-// ListSensors returns all Argo Events Sensors
-func (c *Client) ListSensors(ctx context.Context) ([]types.AsyncResource, error) {
-	var resources []types.AsyncResource
+// ListAll returns the resources of every registered kind, the kinds the
+// user may not list, and the kinds whose listing failed otherwise
+func (c *Client) ListAll(ctx context.Context) ([]types.AsyncResource, []types.ResourceKind, map[types.ResourceKind]error, error) {
+	var all []types.AsyncResource
+	var forbidden []types.ResourceKind
+	failed := make(map[types.ResourceKind]error)
+	apis := c.APIs()
+	for _, k := range kinds.All() {
+		if !apis.Installed(k.Kind) {
+			continue
+		}
+		resources, err := c.listKind(ctx, k)
+		switch {
+		case apierrors.IsForbidden(err):
+			forbidden = append(forbidden, k.Kind)
+		case err != nil:
+			failed[k.Kind] = err
+		}
+		all = append(all, resources...)
+	}
 
-	list, err := c.dynamicClient.Resource(sensorGVR).Namespace(c.namespace).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return resources, nil
+	for _, k := range kinds.All() {
+		if k.Link != nil {
+			k.Link(all)
+		}
 	}
+	all = slices.DeleteFunc(all, func(r types.AsyncResource) bool {
+		k, _ := kinds.Lookup(r.Kind)
+		return k.Hidden
+	})
 
-	for _, item := range list.Items {
-		resources = append(resources, sensorToResource(item))
+	// Pods are listed once per refresh for the annotations that need them
+	var pods []corev1.Pod
+	if list, err := c.clientset.CoreV1().Pods(c.namespace).List(ctx, metav1.ListOptions{}); err == nil {
+		pods = list.Items
 	}
+	annotateJobIssues(all, pods)
+	c.annotateQueueDepth(all)
+	c.annotateAdmission(all)
+	c.annotateWarnings(ctx, all, pods)
 
-	return resources, nil
+	return all, forbidden, failed, nil
 }
 
-// ListEventSources returns all Argo Events EventSources
-func (c *Client) ListEventSources(ctx context.Context) ([]types.AsyncResource, error) {
-	var resources []types.AsyncResource
-
-	list, err := c.dynamicClient.Resource(eventSourceGVR).Namespace(c.namespace).List(ctx, metav1.ListOptions{})
+// listKind lists the objects of a kind in the namespace
+func (c *Client) listKind(ctx context.Context, k kinds.Kind) ([]types.AsyncResource, error) {
+	namespace := c.namespace
+	if k.ClusterScoped {
+		namespace = ""
+	}
+	list, err := c.dynamicClient.Resource(c.gvr(k.Kind)).Namespace(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
-		return resources, nil
+		return nil, listError(err)
 	}
 
+	var resources []types.AsyncResource
 	for _, item := range list.Items {
-		resources = append(resources, eventSourceToResource(item))
+		r, err := k.Convert(item)
+		if err != nil {
+			continue
+		}
+		resources = append(resources, r)
 	}
-
 	return resources, nil
 }
 
-// ListAll returns all async resources
-func (c *Client) ListAll(ctx context.Context) ([]types.AsyncResource, error) {
-	var all []types.AsyncResource
-
-	jobs, _ := c.ListJobs(ctx)
-	all = append(all, jobs...)
-
-	cronJobs, _ := c.ListCronJobs(ctx)
-	all = append(all, cronJobs...)
-
-	workflows, _ := c.ListWorkflows(ctx)
-	all = append(all, workflows...)
-
-	cronWorkflows, _ := c.ListCronWorkflows(ctx)
-	all = append(all, cronWorkflows...)
-
-	sensors, _ := c.ListSensors(ctx)
-	all = append(all, sensors...)
-
-	eventSources, _ := c.ListEventSources(ctx)
-	all = append(all, eventSources...)
+// annotateJobIssues explains unfinished or failed Jobs with the most
+// important reason found on their pods
+func annotateJobIssues(resources []types.AsyncResource, pods []corev1.Pod) {
+	issues := jobPodIssues(pods)
+	for i, r := range resources {
+		// Pod-level reasons explain failures better than the Job condition
+		if issue, ok := issues[k8stypes.UID(r.UID)]; ok && r.Kind == types.KindJob && r.Status != types.StatusSucceeded {
+			resources[i].Message = issue
+		}
+	}
+}
 
-	return all, nil
+// listError drops NotFound errors, which mean the CRD was removed since
+// discovery, or discovery failed and assumed it installed
+func listError(err error) error {
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
 }
 
 // Helper functions to convert k8s resources to AsyncResource
 
 func jobToResource(job batchv1.Job) types.AsyncResource {
 	r := types.AsyncResource{
+		UID:            string(job.UID),
 		Kind:           types.KindJob,
 		Name:           job.Name,
 		Namespace:      job.Namespace,
@@ -259,6 +332,10 @@ func jobToResource(job batchv1.Job) types.AsyncResource {
 			break
 		}
 	}
+	// Other controllers are linked by their kind, e.g. KEDA ScaledJobs
+	if ref := metav1.GetControllerOf(&job); ref != nil {
+		setAttribute(&r, "controller", ref.Kind+"/"+ref.Name)
+	}
 
 	if job.Status.StartTime != nil {
 		t := job.Status.StartTime.Time
@@ -290,11 +367,21 @@ func jobToResource(job batchv1.Job) types.AsyncResource {
 	r.SuccessCount = int(job.Status.Succeeded)
 	r.FailureCount = int(job.Status.Failed)
 
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue {
+			r.Message = cond.Reason
+			if cond.Message != "" {
+				r.Message += ": " + cond.Message
+			}
+		}
+	}
+
 	return r
 }
 
 func cronJobToResource(cj batchv1.CronJob) types.AsyncResource {
 	r := types.AsyncResource{
+		UID:            string(cj.UID),
 		Kind:           types.KindCronJob,
 		Name:           cj.Name,
 		Namespace:      cj.Namespace,
@@ -323,11 +410,17 @@ func cronJobToResource(cj batchv1.CronJob) types.AsyncResource {
 		r.Status = types.StatusRunning
 	}
 
+	if cj.Spec.Suspend != nil && *cj.Spec.Suspend {
+		r.Suspended = true
+		r.Status = types.StatusSuspended
+	}
+
 	return r
 }
 
 func workflowToResource(obj unstructured.Unstructured) types.AsyncResource {
 	r := types.AsyncResource{
+		UID:       string(obj.GetUID()),
 		Kind:      types.KindWorkflow,
 		Name:      obj.GetName(),
 		Namespace: obj.GetNamespace(),
@@ -390,24 +483,17 @@ func workflowToResource(obj unstructured.Unstructured) types.AsyncResource {
 
 		// Extract DAG nodes
 		if nodes, ok := status["nodes"].(map[string]interface{}); ok {
-			for _, nodeData := range nodes {
+			podNameFormat := obj.GetAnnotations()[podNameFormatAnnotation]
+			for id, nodeData := range nodes {
 				if node, ok := nodeData.(map[string]interface{}); ok {
-					dagNode := types.DAGNode{}
-					if name, ok := node["displayName"].(string); ok {
-						dagNode.Name = name
-					}
-					if nodeType, ok := node["type"].(string); ok {
-						dagNode.Type = nodeType
-					}
-					if phase, ok := node["phase"].(string); ok {
-						dagNode.Phase = phase
-					}
+					dagNode := dagNodeFromStatus(id, node, r.Name, podNameFormat)
 					// Only include meaningful nodes (skip empty names)
 					if dagNode.Name != "" {
 						r.DAGNodes = append(r.DAGNodes, dagNode)
 					}
 				}
 			}
+			r.DAGNodes = aggregateRetryNodes(r.DAGNodes)
 		}
 	}
 
@@ -416,6 +502,7 @@ func workflowToResource(obj unstructured.Unstructured) types.AsyncResource {
 
 func cronWorkflowToResource(obj unstructured.Unstructured) types.AsyncResource {
 	r := types.AsyncResource{
+		UID:       string(obj.GetUID()),
 		Kind:      types.KindCronWorkflow,
 		Name:      obj.GetName(),
 		Namespace: obj.GetNamespace(),
@@ -430,6 +517,10 @@ func cronWorkflowToResource(obj unstructured.Unstructured) types.AsyncResource {
 		if timezone, ok := spec["timezone"].(string); ok {
 			r.Timezone = timezone
 		}
+		if suspend, ok := spec["suspend"].(bool); ok && suspend {
+			r.Suspended = true
+			r.Status = types.StatusSuspended
+		}
 		// Extract service account from workflowSpec
 		if wfSpec, ok := spec["workflowSpec"].(map[string]interface{}); ok {
 			if sa, ok := wfSpec["serviceAccountName"].(string); ok {
@@ -452,6 +543,7 @@ func cronWorkflowToResource(obj unstructured.Unstructured) types.AsyncResource {
 
 func sensorToResource(obj unstructured.Unstructured) types.AsyncResource {
 	r := types.AsyncResource{
+		UID:       string(obj.GetUID()),
 		Kind:      types.KindSensor,
 		Name:      obj.GetName(),
 		Namespace: obj.GetNamespace(),
@@ -526,6 +618,7 @@ func sensorToResource(obj unstructured.Unstructured) types.AsyncResource {
 
 func eventSourceToResource(obj unstructured.Unstructured) types.AsyncResource {
 	r := types.AsyncResource{
+		UID:       string(obj.GetUID()),
 		Kind:      types.KindEventSource,
 		Name:      obj.GetName(),
 		Namespace: obj.GetNamespace(),
@@ -0,0 +1,231 @@
+package k8s
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ginbear/k8s-flowtop/internal/types"
+)
+
+// podNameFormatAnnotation selects how Argo names workflow pods ("v1" or "v2")
+const podNameFormatAnnotation = "workflows.argoproj.io/pod-name-format"
+
+// maxPodNamePrefixLength keeps generated pod names within the k8s name limit
+const maxPodNamePrefixLength = 253 - 10 - 1
+
+// dagNodeFromStatus converts an entry of a Workflow's status.nodes
+func dagNodeFromStatus(id string, node map[string]interface{}, workflowName, podNameFormat string) types.DAGNode {
+	dagNode := types.DAGNode{ID: id}
+	if name, ok := node["displayName"].(string); ok {
+		dagNode.Name = name
+	}
+	if nodeType, ok := node["type"].(string); ok {
+		dagNode.Type = nodeType
+	}
+	if phase, ok := node["phase"].(string); ok {
+		dagNode.Phase = phase
+	}
+	if tmpl, ok := node["templateName"].(string); ok {
+		dagNode.TemplateName = tmpl
+	}
+	if boundary, ok := node["boundaryID"].(string); ok {
+		dagNode.BoundaryID = boundary
+	}
+	if children, ok := node["children"].([]interface{}); ok {
+		for _, c := range children {
+			if childID, ok := c.(string); ok {
+				dagNode.Children = append(dagNode.Children, childID)
+			}
+		}
+	}
+	if msg, ok := node["message"].(string); ok {
+		dagNode.Message = msg
+	}
+	if startedAt, ok := node["startedAt"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, startedAt); err == nil {
+			dagNode.StartedAt = &t
+		}
+	}
+	if finishedAt, ok := node["finishedAt"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, finishedAt); err == nil {
+			dagNode.FinishedAt = &t
+		}
+	}
+	if dagNode.StartedAt != nil {
+		if dagNode.FinishedAt != nil {
+			dagNode.Duration = dagNode.FinishedAt.Sub(*dagNode.StartedAt)
+		} else {
+			dagNode.Duration = time.Since(*dagNode.StartedAt)
+		}
+	}
+	if host, ok := node["hostNodeName"].(string); ok {
+		dagNode.HostNodeName = host
+	}
+
+	// Pod name is recorded by newer controllers; otherwise derive it
+	if podName, ok := node["podName"].(string); ok {
+		dagNode.PodName = podName
+	} else if dagNode.Type == "Pod" {
+		nodeName, _ := node["name"].(string)
+		dagNode.PodName = argoPodName(workflowName, nodeName, dagNode.TemplateName, id, podNameFormat)
+	}
+
+	if inputs, ok := node["inputs"].(map[string]interface{}); ok {
+		dagNode.Inputs = nodeIO(inputs)
+	}
+	if outputs, ok := node["outputs"].(map[string]interface{}); ok {
+		dagNode.Outputs = nodeIO(outputs)
+		if code, ok := outputs["exitCode"].(string); ok {
+			dagNode.ExitCode = code
+		}
+	}
+	if rd, ok := node["resourcesDuration"].(map[string]interface{}); ok {
+		dagNode.ResourcesDuration = make(map[string]int64)
+		for name, v := range rd {
+			switch n := v.(type) {
+			case int64:
+				dagNode.ResourcesDuration[name] = n
+			case float64:
+				dagNode.ResourcesDuration[name] = int64(n)
+			}
+		}
+	}
+
+	return dagNode
+}
+
+// nodeIO extracts parameters and artifacts from node inputs/outputs
+func nodeIO(io map[string]interface{}) []types.NodeIO {
+	var result []types.NodeIO
+	if params, ok := io["parameters"].([]interface{}); ok {
+		for _, p := range params {
+			if param, ok := p.(map[string]interface{}); ok {
+				name, _ := param["name"].(string)
+				value := fmt.Sprint(param["value"])
+				if param["value"] == nil {
+					value = ""
+				}
+				result = append(result, types.NodeIO{Name: name, Value: value})
+			}
+		}
+	}
+	if artifacts, ok := io["artifacts"].([]interface{}); ok {
+		for _, a := range artifacts {
+			if artifact, ok := a.(map[string]interface{}); ok {
+				name, _ := artifact["name"].(string)
+				key, _ := artifact["path"].(string)
+				if s3, ok := artifact["s3"].(map[string]interface{}); ok {
+					if k, ok := s3["key"].(string); ok {
+						key = k
+					}
+				}
+				result = append(result, types.NodeIO{Name: name, Value: key, Artifact: true})
+			}
+		}
+	}
+	if res, ok := io["result"].(string); ok {
+		result = append(result, types.NodeIO{Name: "result", Value: res})
+	}
+	return result
+}
+
+// argoPodName reproduces the pod naming of the Argo controller
+func argoPodName(workflowName, nodeName, templateName, nodeID, format string) string {
+	if format == "v1" {
+		return nodeID
+	}
+	if workflowName == nodeName {
+		return workflowName
+	}
+
+	prefix := workflowName
+	if !strings.Contains(nodeName, ".inline") {
+		prefix = fmt.Sprintf("%s-%s", workflowName, templateName)
+	}
+	if len(prefix) > maxPodNamePrefixLength {
+		prefix = prefix[:maxPodNamePrefixLength]
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(nodeName))
+	return fmt.Sprintf("%s-%v", prefix, h.Sum32())
+}
+
+// aggregateRetryNodes folds the attempts of each Retry node into the node
+// itself: the attempts are dropped, their children are re-parented to the
+// Retry node and the last attempt's pod details are kept
+func aggregateRetryNodes(nodes []types.DAGNode) []types.DAGNode {
+	byID := make(map[string]int, len(nodes))
+	for i, n := range nodes {
+		byID[n.ID] = i
+	}
+
+	attempts := make(map[string]bool)
+	for i, n := range nodes {
+		if n.Type != "Retry" {
+			continue
+		}
+
+		var tries []types.DAGNode
+		for _, c := range n.Children {
+			if j, ok := byID[c]; ok {
+				tries = append(tries, nodes[j])
+			}
+		}
+		if len(tries) == 0 {
+			continue
+		}
+		sort.SliceStable(tries, func(a, b int) bool {
+			if tries[a].StartedAt == nil {
+				return false
+			}
+			if tries[b].StartedAt == nil {
+				return true
+			}
+			return tries[a].StartedAt.Before(*tries[b].StartedAt)
+		})
+
+		seen := make(map[string]bool)
+		var children []string
+		for _, t := range tries {
+			attempts[t.ID] = true
+			for _, c := range t.Children {
+				if !seen[c] {
+					seen[c] = true
+					children = append(children, c)
+				}
+			}
+		}
+
+		last := tries[len(tries)-1]
+		nodes[i].Children = children
+		nodes[i].Attempts = len(tries)
+		nodes[i].PodName = last.PodName
+		nodes[i].HostNodeName = last.HostNodeName
+		nodes[i].ExitCode = last.ExitCode
+		nodes[i].ResourcesDuration = last.ResourcesDuration
+		if len(nodes[i].Inputs) == 0 {
+			nodes[i].Inputs = last.Inputs
+		}
+		if len(nodes[i].Outputs) == 0 {
+			nodes[i].Outputs = last.Outputs
+		}
+		if nodes[i].Message == "" {
+			nodes[i].Message = last.Message
+		}
+	}
+
+	if len(attempts) == 0 {
+		return nodes
+	}
+	result := make([]types.DAGNode, 0, len(nodes)-len(attempts))
+	for _, n := range nodes {
+		if !attempts[n.ID] {
+			result = append(result, n)
+		}
+	}
+	return result
+}
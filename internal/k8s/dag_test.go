@@ -0,0 +1,107 @@
+package k8s
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ginbear/k8s-flowtop/internal/types"
+)
+
+func TestArgoPodName(t *testing.T) {
+	long := strings.Repeat("w", 250)
+	tests := []struct {
+		name                                     string
+		workflow, node, template, nodeID, format string
+		want                                     string
+	}{
+		{
+			name:     "v1 uses the node ID",
+			workflow: "hello-abc", node: "hello-abc.step", template: "echo", nodeID: "hello-abc-123", format: "v1",
+			want: "hello-abc-123",
+		},
+		{
+			name:     "root node",
+			workflow: "hello-abc", node: "hello-abc", template: "echo", nodeID: "hello-abc",
+			want: "hello-abc",
+		},
+		{
+			name:     "v2 prefixes the template",
+			workflow: "hello-abc", node: "hello-abc.step", template: "echo", nodeID: "hello-abc-123", format: "v2",
+			want: "hello-abc-echo-2698161002",
+		},
+		{
+			name:     "inline template",
+			workflow: "hello-abc", node: "hello-abc.inline", template: "echo", nodeID: "hello-abc-123",
+			want: "hello-abc-71802549",
+		},
+		{
+			name:     "long prefix is truncated",
+			workflow: long, node: "hello-abc.step", template: "echo", nodeID: "x",
+			want: long[:maxPodNamePrefixLength] + "-2698161002",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := argoPodName(tt.workflow, tt.node, tt.template, tt.nodeID, tt.format); got != tt.want {
+				t.Errorf("argoPodName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregateRetryNodes(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+
+	tests := []struct {
+		name  string
+		nodes []types.DAGNode
+		want  []types.DAGNode
+	}{
+		{
+			name:  "no retries",
+			nodes: []types.DAGNode{{ID: "a", Type: "Pod", Children: []string{"b"}}, {ID: "b", Type: "Pod"}},
+			want:  []types.DAGNode{{ID: "a", Type: "Pod", Children: []string{"b"}}, {ID: "b", Type: "Pod"}},
+		},
+		{
+			name: "attempts folded into the retry node",
+			nodes: []types.DAGNode{
+				{ID: "r", Type: "Retry", Children: []string{"try2", "try1"}},
+				{ID: "try1", Type: "Pod", StartedAt: &t0, PodName: "pod-1", ExitCode: "1", Message: "failed", Children: []string{"next"}},
+				{ID: "try2", Type: "Pod", StartedAt: &t1, PodName: "pod-2", ExitCode: "0", Children: []string{"next", "after"}},
+				{ID: "next", Type: "Pod"},
+				{ID: "after", Type: "Pod"},
+			},
+			want: []types.DAGNode{
+				{ID: "r", Type: "Retry", Children: []string{"next", "after"}, Attempts: 2, PodName: "pod-2", ExitCode: "0"},
+				{ID: "next", Type: "Pod"},
+				{ID: "after", Type: "Pod"},
+			},
+		},
+		{
+			name: "unstarted attempt is last",
+			nodes: []types.DAGNode{
+				{ID: "r", Type: "Retry", Children: []string{"pending", "try1"}, Message: "retrying"},
+				{ID: "try1", Type: "Pod", StartedAt: &t0, PodName: "pod-1", Message: "failed"},
+				{ID: "pending", Type: "Pod"},
+			},
+			want: []types.DAGNode{
+				{ID: "r", Type: "Retry", Attempts: 2, Message: "retrying"},
+			},
+		},
+		{
+			name:  "retry without listed attempts",
+			nodes: []types.DAGNode{{ID: "r", Type: "Retry", Children: []string{"gone"}}},
+			want:  []types.DAGNode{{ID: "r", Type: "Retry", Children: []string{"gone"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := aggregateRetryNodes(tt.nodes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("aggregateRetryNodes() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
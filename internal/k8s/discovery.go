@@ -0,0 +1,207 @@
+package k8s
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/ginbear/k8s-flowtop/internal/kinds"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+// Discovery limits
+const (
+	discoveryTimeout  = 10 * time.Second
+	discoveryCacheTTL = 10 * time.Minute
+)
+
+// Discovery records which async kinds a cluster serves, and at which version
+type Discovery struct {
+	FetchedAt time.Time `json:"fetchedAt"`
+
+	// Kinds maps each installed kind to the resource it is read from; nil
+	// before discovery returns
+	Kinds map[types.ResourceKind]schema.GroupVersionResource `json:"kinds"`
+
+	// Versions lists every version served for each installed kind
+	Versions map[types.ResourceKind][]string `json:"versions"`
+
+	// Checked lists the kinds looked up, so a cache missing newly
+	// registered kinds is not used
+	Checked []types.ResourceKind `json:"checked"`
+
+	// Err is set when discovery failed; all kinds are then assumed installed
+	Err string `json:"-"`
+}
+
+// Installed reports whether the cluster serves a kind
+func (d Discovery) Installed(kind types.ResourceKind) bool {
+	if d.Kinds == nil {
+		return true
+	}
+	_, ok := d.Kinds[kind]
+	return ok
+}
+
+// APIs returns the async kinds discovered on the cluster
+func (c *Client) APIs() Discovery {
+	c.apisMu.RLock()
+	defer c.apisMu.RUnlock()
+	return c.apis
+}
+
+// Discover finds the async kinds the cluster serves. It may take up to
+// the discovery timeout, so callers run it in the background.
+func (c *Client) Discover() Discovery {
+	return discover(c.config, c.server)
+}
+
+// SetAPIs records the result of Discover
+func (c *Client) SetAPIs(d Discovery) {
+	c.apisMu.Lock()
+	defer c.apisMu.Unlock()
+	c.apis = d
+}
+
+// discover finds the async kinds the cluster serves, using a cache
+// per cluster server
+func discover(config *rest.Config, server string) Discovery {
+	cachePath := discoveryCachePath(server)
+	if d, ok := readDiscoveryCache(cachePath); ok {
+		return d
+	}
+
+	config = rest.CopyConfig(config)
+	config.Timeout = discoveryTimeout
+	dc, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return Discovery{Err: err.Error()}
+	}
+	d, err := discoverAPIs(dc)
+	if err != nil {
+		return Discovery{Err: err.Error()}
+	}
+	writeDiscoveryCache(cachePath, d)
+	return d
+}
+
+// discoverAPIs asks the API server which versions serve each kind,
+// preferring the group's preferred version
+func discoverAPIs(dc discovery.DiscoveryInterface) (Discovery, error) {
+	groups, err := dc.ServerGroups()
+	if err != nil {
+		return Discovery{}, err
+	}
+
+	d := Discovery{
+		FetchedAt: time.Now(),
+		Kinds:     make(map[types.ResourceKind]schema.GroupVersionResource),
+		Versions:  make(map[types.ResourceKind][]string),
+	}
+	served := make(map[string]*metav1.APIResourceList)
+
+	for _, k := range kinds.All() {
+		kind, want := k.Kind, k.GVR
+		d.Checked = append(d.Checked, kind)
+
+		var group *metav1.APIGroup
+		for i := range groups.Groups {
+			if groups.Groups[i].Name == want.Group {
+				group = &groups.Groups[i]
+				break
+			}
+		}
+		if group == nil {
+			continue
+		}
+
+		// Preferred version first
+		versions := []metav1.GroupVersionForDiscovery{group.PreferredVersion}
+		versions = append(versions, group.Versions...)
+		for _, v := range versions {
+			resources, ok := served[v.GroupVersion]
+			if !ok {
+				resources, _ = dc.ServerResourcesForGroupVersion(v.GroupVersion)
+				served[v.GroupVersion] = resources
+			}
+			if resources == nil || !hasResource(resources, want.Resource) {
+				continue
+			}
+			if _, found := d.Kinds[kind]; !found {
+				d.Kinds[kind] = schema.GroupVersionResource{Group: want.Group, Version: v.Version, Resource: want.Resource}
+			}
+			if !slices.Contains(d.Versions[kind], v.Version) {
+				d.Versions[kind] = append(d.Versions[kind], v.Version)
+			}
+		}
+	}
+	return d, nil
+}
+
+func hasResource(list *metav1.APIResourceList, resource string) bool {
+	for _, r := range list.APIResources {
+		if r.Name == resource {
+			return true
+		}
+	}
+	return false
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// discoveryCachePath returns the cache file for a cluster server, or ""
+func discoveryCachePath(server string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil || server == "" {
+		return ""
+	}
+	return filepath.Join(dir, "flowtop", "discovery", unsafeFileChars.ReplaceAllString(server, "_")+".json")
+}
+
+// readDiscoveryCache returns a cached discovery result if it is fresh
+func readDiscoveryCache(path string) (Discovery, bool) {
+	if path == "" {
+		return Discovery{}, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Discovery{}, false
+	}
+	var d Discovery
+	if err := json.Unmarshal(data, &d); err != nil || time.Since(d.FetchedAt) > discoveryCacheTTL {
+		return Discovery{}, false
+	}
+	for _, k := range kinds.All() {
+		if !slices.Contains(d.Checked, k.Kind) {
+			return Discovery{}, false
+		}
+		// A kind mapping may have been edited since
+		if gvr, ok := d.Kinds[k.Kind]; ok && (gvr.Group != k.GVR.Group || gvr.Resource != k.GVR.Resource) {
+			return Discovery{}, false
+		}
+	}
+	return d, true
+}
+
+// writeDiscoveryCache stores a discovery result; failures only cost a
+// rediscovery next time
+func writeDiscoveryCache(path string, d Discovery) {
+	if path == "" {
+		return
+	}
+	data, err := json.Marshal(d)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o600)
+}
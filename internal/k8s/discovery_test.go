@@ -0,0 +1,147 @@
+package k8s
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ginbear/k8s-flowtop/internal/kinds"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestDiscoverAPIs(t *testing.T) {
+	resources := func(names ...string) []metav1.APIResource {
+		var rs []metav1.APIResource
+		for _, n := range names {
+			rs = append(rs, metav1.APIResource{Name: n})
+		}
+		return rs
+	}
+	dc := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{}}
+	dc.Resources = []*metav1.APIResourceList{
+		// The first version of a group is its preferred one
+		{GroupVersion: "batch/v1", APIResources: resources("jobs", "cronjobs")},
+		{GroupVersion: "argoproj.io/v1beta1", APIResources: resources("workflows")},
+		{GroupVersion: "argoproj.io/v1alpha1", APIResources: resources("workflows", "cronworkflows")},
+	}
+
+	d, err := discoverAPIs(dc)
+	if err != nil {
+		t.Fatalf("discoverAPIs() error = %v", err)
+	}
+
+	tests := []struct {
+		kind         types.ResourceKind
+		wantVersion  string
+		wantVersions []string
+	}{
+		{types.KindJob, "v1", []string{"v1"}},
+		{types.KindCronJob, "v1", []string{"v1"}},
+		{types.KindWorkflow, "v1beta1", []string{"v1beta1", "v1alpha1"}},
+		{types.KindCronWorkflow, "v1alpha1", []string{"v1alpha1"}},
+		{types.KindSensor, "", nil},
+		{kindScaledJob, "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			gvr, ok := d.Kinds[tt.kind]
+			if tt.wantVersion == "" {
+				if ok || d.Installed(tt.kind) {
+					t.Errorf("%s is installed at %v, want not installed", tt.kind, gvr)
+				}
+				return
+			}
+			if !d.Installed(tt.kind) || gvr.Version != tt.wantVersion {
+				t.Errorf("%s = %v, want version %s", tt.kind, gvr, tt.wantVersion)
+			}
+			if !reflect.DeepEqual(d.Versions[tt.kind], tt.wantVersions) {
+				t.Errorf("%s versions = %v, want %v", tt.kind, d.Versions[tt.kind], tt.wantVersions)
+			}
+		})
+	}
+
+	if len(d.Checked) != len(kinds.All()) {
+		t.Errorf("checked %d kinds, want %d", len(d.Checked), len(kinds.All()))
+	}
+}
+
+func TestDiscoveryBeforeDiscover(t *testing.T) {
+	var d Discovery
+	if !d.Installed(types.KindWorkflow) {
+		t.Error("kinds should be assumed installed before discovery")
+	}
+}
+
+func TestDiscoveryCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	pathA := discoveryCachePath("https://cluster-a.example.com:6443")
+	pathB := discoveryCachePath("https://cluster-b.example.com:6443")
+	if pathA == "" || pathA == pathB {
+		t.Fatalf("cache paths %q and %q should differ per server", pathA, pathB)
+	}
+	if discoveryCachePath("") != "" {
+		t.Error("a client without a server should not be cached")
+	}
+
+	fresh := func() Discovery {
+		d := Discovery{
+			FetchedAt: time.Now(),
+			Kinds: map[types.ResourceKind]schema.GroupVersionResource{
+				types.KindWorkflow: {Group: "argoproj.io", Version: "v1alpha1", Resource: "workflows"},
+			},
+			Versions: map[types.ResourceKind][]string{types.KindWorkflow: {"v1alpha1"}},
+		}
+		for _, k := range kinds.All() {
+			d.Checked = append(d.Checked, k.Kind)
+		}
+		return d
+	}
+
+	// Written for one server, read back only for that server
+	writeDiscoveryCache(pathA, fresh())
+	d, ok := readDiscoveryCache(pathA)
+	if !ok || d.Kinds[types.KindWorkflow].Version != "v1alpha1" {
+		t.Errorf("readDiscoveryCache() = %+v, %v, want the cached result", d, ok)
+	}
+	if _, ok := readDiscoveryCache(pathB); ok {
+		t.Error("another server's cache was used")
+	}
+	if info, err := os.Stat(pathA); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("cache file mode = %v, %v, want 0600", info, err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Discovery)
+	}{
+		{"expired", func(d *Discovery) { d.FetchedAt = time.Now().Add(-discoveryCacheTTL - time.Minute) }},
+		{"missing a registered kind", func(d *Discovery) { d.Checked = d.Checked[1:] }},
+		{"mapping changed", func(d *Discovery) {
+			d.Kinds[types.KindWorkflow] = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "other"}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := fresh()
+			tt.modify(&d)
+			writeDiscoveryCache(pathA, d)
+			if _, ok := readDiscoveryCache(pathA); ok {
+				t.Error("stale cache was used")
+			}
+		})
+	}
+
+	if err := os.WriteFile(pathA, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := readDiscoveryCache(pathA); ok {
+		t.Error("unparsable cache was used")
+	}
+}
@@ -0,0 +1,313 @@
+package k8s
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ginbear/k8s-flowtop/internal/kinds"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// eventRetryInterval is the pause before re-establishing a broken event watch
+const eventRetryInterval = 5 * time.Second
+
+// workloadOwner identifies the async workload an object belongs to
+type workloadOwner struct {
+	kind types.ResourceKind
+	name string
+}
+
+// maxPodEventQueries is the number of pods whose Events are fetched one
+// by one; beyond it, Pod Events are fetched at once and filtered
+const maxPodEventQueries = 10
+
+// ListEvents returns the Events of a resource and its pods, oldest first.
+// Events are fetched by involvedObject, not by listing the namespace.
+func (c *Client) ListEvents(ctx context.Context, r types.AsyncResource) ([]types.EventInfo, error) {
+	involved := fields.Set{"involvedObject.uid": r.UID}
+	if r.UID == "" {
+		involved = fields.Set{"involvedObject.kind": string(r.Kind), "involvedObject.name": r.Name}
+	}
+	events, err := c.listEvents(ctx, r.Namespace, involved)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pod events explain most failures, so include the resource's pods
+	pods, _ := c.ListPods(ctx, r)
+	if len(pods) <= maxPodEventQueries {
+		for _, p := range pods {
+			podEvents, err := c.listEvents(ctx, r.Namespace, fields.Set{"involvedObject.kind": "Pod", "involvedObject.name": p.Name})
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, podEvents...)
+		}
+	} else {
+		podNames := make(map[string]bool)
+		for _, p := range pods {
+			podNames[p.Name] = true
+		}
+		podEvents, err := c.listEvents(ctx, r.Namespace, fields.Set{"involvedObject.kind": "Pod"})
+		if err != nil {
+			return nil, err
+		}
+		for _, ev := range podEvents {
+			if podNames[ev.InvolvedObject.Name] {
+				events = append(events, ev)
+			}
+		}
+	}
+
+	result := make([]types.EventInfo, 0, len(events))
+	seen := make(map[k8stypes.UID]bool)
+	for _, ev := range events {
+		if seen[ev.UID] {
+			continue
+		}
+		seen[ev.UID] = true
+		result = append(result, eventToInfo(ev))
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].LastSeen.Before(result[j].LastSeen)
+	})
+	return result, nil
+}
+
+// listEvents returns the Events whose fields match
+func (c *Client) listEvents(ctx context.Context, namespace string, match fields.Set) ([]corev1.Event, error) {
+	list, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: match.AsSelector().String()})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// annotateWarnings sets the Warning count and latest Warning of each resource,
+// attributing pod Warnings to the Job or Workflow owning the pod
+func (c *Client) annotateWarnings(ctx context.Context, resources []types.AsyncResource, pods []corev1.Pod) {
+	events, err := c.clientset.CoreV1().Events(c.namespace).List(ctx, metav1.ListOptions{FieldSelector: "type=Warning"})
+	if err != nil || len(events.Items) == 0 {
+		return
+	}
+
+	byUID := make(map[string]int)
+	byName := make(map[string]int)
+	for i, r := range resources {
+		if r.UID != "" {
+			byUID[r.UID] = i
+		}
+		byName[string(r.Kind)+"/"+r.Namespace+"/"+r.Name] = i
+	}
+
+	// Map pod UIDs to the index of their owning resource
+	podOwners := make(map[string]int)
+	for _, pod := range pods {
+		if owner := metav1.GetControllerOf(&pod); owner != nil && pod.Labels[jobNameLabel] != "" {
+			if i, ok := byUID[string(owner.UID)]; ok {
+				podOwners[string(pod.UID)] = i
+			}
+		}
+		if name := pod.Labels[workflowLabel]; name != "" {
+			if i, ok := byName[string(types.KindWorkflow)+"/"+pod.Namespace+"/"+name]; ok {
+				podOwners[string(pod.UID)] = i
+			}
+		}
+	}
+
+	latest := make(map[int]time.Time)
+	for _, ev := range events.Items {
+		obj := ev.InvolvedObject
+		i, ok := byUID[string(obj.UID)]
+		if !ok {
+			i, ok = podOwners[string(obj.UID)]
+		}
+		if !ok {
+			i, ok = byName[obj.Kind+"/"+obj.Namespace+"/"+obj.Name]
+		}
+		if !ok {
+			continue
+		}
+
+		info := eventToInfo(ev)
+		resources[i].WarningCount += info.Count
+		if info.LastSeen.After(latest[i]) || resources[i].LastWarning == "" {
+			latest[i] = info.LastSeen
+			resources[i].LastWarning = info.Reason + ": " + info.Message
+		}
+	}
+}
+
+// WatchEvents streams Events about async workloads and their pods in the
+// watched namespaces. Existing Events are sent first; the watch is
+// re-established until ctx is cancelled, so Events may be sent again.
+func (c *Client) WatchEvents(ctx context.Context) <-chan types.EventInfo {
+	ch := make(chan types.EventInfo, 256)
+	go func() {
+		defer close(ch)
+
+		// Pod owners are cached for the lifetime of the stream
+		owners := make(podOwners)
+		send := func(ev corev1.Event, lookup bool) bool {
+			owner := c.eventOwner(ctx, ev.InvolvedObject, owners, lookup)
+			if owner == nil {
+				return true
+			}
+			info := eventToInfo(ev)
+			info.OwnerKind = owner.kind
+			info.OwnerName = owner.name
+			select {
+			case ch <- info:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for ctx.Err() == nil {
+			if !c.streamEvents(ctx, owners, send) {
+				return
+			}
+			select {
+			case <-time.After(eventRetryInterval):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// streamEvents lists then watches Events, passing each to send.
+// It returns false once send reports that the stream was cancelled.
+func (c *Client) streamEvents(ctx context.Context, owners podOwners, send func(ev corev1.Event, lookup bool) bool) bool {
+	// Listed Events resolve their pods from a single pod list; pods
+	// missing from it are gone. Pods that show up later are read one by one.
+	listed := c.listPodOwners(ctx, owners)
+
+	events := c.clientset.CoreV1().Events(c.namespace)
+	list, err := events.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return true
+	}
+	sort.SliceStable(list.Items, func(i, j int) bool {
+		return eventToInfo(list.Items[i]).LastSeen.Before(eventToInfo(list.Items[j]).LastSeen)
+	})
+	for _, ev := range list.Items {
+		if !send(ev, !listed) {
+			return false
+		}
+	}
+
+	w, err := events.Watch(ctx, metav1.ListOptions{ResourceVersion: list.ResourceVersion})
+	if err != nil {
+		return true
+	}
+	defer w.Stop()
+	for e := range w.ResultChan() {
+		if e.Type != watch.Added && e.Type != watch.Modified {
+			continue
+		}
+		if ev, ok := e.Object.(*corev1.Event); ok && !send(*ev, true) {
+			return false
+		}
+	}
+	return true
+}
+
+// podOwners maps pod UIDs to the workload owning them; nil marks pods
+// owned by none, or gone
+type podOwners map[string]*workloadOwner
+
+// listPodOwners adds the owners of the pods in the watched namespaces,
+// reporting whether the pods could be listed
+func (c *Client) listPodOwners(ctx context.Context, owners podOwners) bool {
+	pods, err := c.clientset.CoreV1().Pods(c.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false
+	}
+	for i := range pods.Items {
+		owners[string(pods.Items[i].UID)] = podOwner(&pods.Items[i])
+	}
+	return true
+}
+
+// eventOwner returns the async workload an Event's object belongs to, or
+// nil. Pods missing from the cache are read if lookup is set.
+func (c *Client) eventOwner(ctx context.Context, obj corev1.ObjectReference, cache podOwners, lookup bool) *workloadOwner {
+	// Events of registered kinds are streamed as they are
+	if k, ok := kinds.Lookup(types.ResourceKind(obj.Kind)); ok {
+		return &workloadOwner{kind: k.Kind, name: obj.Name}
+	}
+	if obj.Kind != "Pod" {
+		return nil
+	}
+	if owner, ok := cache[string(obj.UID)]; ok || !lookup {
+		return owner
+	}
+
+	var owner *workloadOwner
+	pod, err := c.clientset.CoreV1().Pods(obj.Namespace).Get(ctx, obj.Name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err == nil {
+		owner = podOwner(pod)
+	}
+	cache[string(obj.UID)] = owner
+	return owner
+}
+
+// podOwner returns the async workload a pod runs for, or nil
+func podOwner(pod *corev1.Pod) *workloadOwner {
+	for _, k := range kinds.All() {
+		if name := pod.Labels[k.PodLabel]; k.PodLabel != "" && name != "" {
+			return &workloadOwner{kind: k.Kind, name: name}
+		}
+	}
+	if ref := metav1.GetControllerOf(pod); ref != nil && ref.Kind == "Job" {
+		return &workloadOwner{kind: types.KindJob, name: ref.Name}
+	}
+	return nil
+}
+
+func eventToInfo(ev corev1.Event) types.EventInfo {
+	info := types.EventInfo{
+		UID:       string(ev.UID),
+		Type:      ev.Type,
+		Reason:    ev.Reason,
+		Message:   ev.Message,
+		Kind:      ev.InvolvedObject.Kind,
+		Name:      ev.InvolvedObject.Name,
+		Namespace: ev.InvolvedObject.Namespace,
+		Count:     int(ev.Count),
+		FirstSeen: ev.FirstTimestamp.Time,
+		LastSeen:  ev.LastTimestamp.Time,
+	}
+
+	// events.k8s.io/v1 writers leave the legacy fields empty
+	if ev.Series != nil {
+		info.Count = int(ev.Series.Count)
+		info.LastSeen = ev.Series.LastObservedTime.Time
+	}
+	if info.FirstSeen.IsZero() {
+		info.FirstSeen = ev.EventTime.Time
+	}
+	if info.FirstSeen.IsZero() {
+		info.FirstSeen = ev.CreationTimestamp.Time
+	}
+	if info.LastSeen.IsZero() {
+		info.LastSeen = info.FirstSeen
+	}
+	if info.Count == 0 {
+		info.Count = 1
+	}
+	return info
+}
@@ -0,0 +1,148 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ginbear/k8s-flowtop/internal/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func testEvent(name string, obj corev1.ObjectReference, lastSeen time.Time) *corev1.Event {
+	obj.Namespace = "default"
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default", UID: k8stypes.UID(name)},
+		InvolvedObject: obj,
+		Reason:         "Test",
+		Message:        name,
+		Count:          1,
+		LastTimestamp:  metav1.NewTime(lastSeen),
+	}
+}
+
+// eventClient returns a client whose Event lists honor field selectors,
+// which the fake clientset ignores, and records the selectors used
+func eventClient(t *testing.T, objects ...runtime.Object) (*Client, *[]string) {
+	cs := fake.NewSimpleClientset(objects...)
+	var selectors []string
+	cs.PrependReactor("list", "events", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		selector := action.(k8stesting.ListAction).GetListRestrictions().Fields
+		selectors = append(selectors, selector.String())
+		if selector.Empty() {
+			t.Error("events listed without a field selector")
+		}
+
+		var list corev1.EventList
+		for _, obj := range objects {
+			ev, ok := obj.(*corev1.Event)
+			if !ok {
+				continue
+			}
+			set := fields.Set{
+				"involvedObject.uid":  string(ev.InvolvedObject.UID),
+				"involvedObject.kind": ev.InvolvedObject.Kind,
+				"involvedObject.name": ev.InvolvedObject.Name,
+			}
+			if selector.Matches(set) {
+				list.Items = append(list.Items, *ev)
+			}
+		}
+		return true, &list, nil
+	})
+	return &Client{clientset: cs}, &selectors
+}
+
+func TestListEvents(t *testing.T) {
+	now := time.Now()
+	labels := map[string]string{workflowLabel: "build"}
+	c, selectors := eventClient(t,
+		testPod("build-1", labels, now),
+		testPod("build-2", labels, now),
+		testEvent("wf", corev1.ObjectReference{Kind: "Workflow", Name: "build", UID: "wf-uid"}, now),
+		testEvent("old-wf", corev1.ObjectReference{Kind: "Workflow", Name: "build", UID: "old-uid"}, now),
+		testEvent("pod-1", corev1.ObjectReference{Kind: "Pod", Name: "build-1"}, now.Add(-time.Minute)),
+		testEvent("pod-2", corev1.ObjectReference{Kind: "Pod", Name: "build-2"}, now.Add(time.Minute)),
+		testEvent("other", corev1.ObjectReference{Kind: "Pod", Name: "web"}, now),
+	)
+
+	events, err := c.ListEvents(context.Background(), types.AsyncResource{
+		UID:       "wf-uid",
+		Kind:      types.KindWorkflow,
+		Name:      "build",
+		Namespace: "default",
+	})
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+	var got []string
+	for _, ev := range events {
+		got = append(got, ev.Message)
+	}
+	if want := []string{"pod-1", "wf", "pod-2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListEvents() = %v, want %v", got, want)
+	}
+
+	sort.Strings(*selectors)
+	want := []string{
+		"involvedObject.kind=Pod,involvedObject.name=build-1",
+		"involvedObject.kind=Pod,involvedObject.name=build-2",
+		"involvedObject.uid=wf-uid",
+	}
+	if !reflect.DeepEqual(*selectors, want) {
+		t.Errorf("field selectors = %v, want %v", *selectors, want)
+	}
+}
+
+func TestListEventsWithoutUID(t *testing.T) {
+	c, selectors := eventClient(t,
+		testEvent("wf", corev1.ObjectReference{Kind: "Workflow", Name: "build", UID: "wf-uid"}, time.Now()),
+	)
+
+	events, err := c.ListEvents(context.Background(), types.AsyncResource{Kind: types.KindWorkflow, Name: "build", Namespace: "default"})
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("ListEvents() returned %d events, want 1", len(events))
+	}
+	if want := []string{"involvedObject.kind=Workflow,involvedObject.name=build"}; !reflect.DeepEqual(*selectors, want) {
+		t.Errorf("field selectors = %v, want %v", *selectors, want)
+	}
+}
+
+func TestListEventsManyPods(t *testing.T) {
+	labels := map[string]string{workflowLabel: "build"}
+	objects := []runtime.Object{
+		testEvent("other", corev1.ObjectReference{Kind: "Pod", Name: "web"}, time.Now()),
+	}
+	for i := range maxPodEventQueries + 1 {
+		name := fmt.Sprintf("build-%d", i)
+		objects = append(objects,
+			testPod(name, labels, time.Now()),
+			testEvent(name, corev1.ObjectReference{Kind: "Pod", Name: name}, time.Now()),
+		)
+	}
+	c, selectors := eventClient(t, objects...)
+
+	events, err := c.ListEvents(context.Background(), types.AsyncResource{UID: "wf-uid", Kind: types.KindWorkflow, Name: "build", Namespace: "default"})
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+	if len(events) != maxPodEventQueries+1 {
+		t.Errorf("ListEvents() returned %d events, want %d", len(events), maxPodEventQueries+1)
+	}
+	sort.Strings(*selectors)
+	if want := []string{"involvedObject.kind=Pod", "involvedObject.uid=wf-uid"}; !reflect.DeepEqual(*selectors, want) {
+		t.Errorf("field selectors = %v, want %v", *selectors, want)
+	}
+}
@@ -0,0 +1,277 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ginbear/k8s-flowtop/internal/kinds"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// KEDA kinds
+const (
+	kindScaledJob    types.ResourceKind = "ScaledJob"
+	kindScaledObject types.ResourceKind = "ScaledObject"
+)
+
+// scaledObjectLabel selects the external metrics of a ScaledObject
+const scaledObjectLabel = "scaledobject.keda.sh/name"
+
+// Replica bounds KEDA uses when the spec leaves them out
+const (
+	kedaDefaultMinReplicas = 0
+	kedaDefaultMaxReplicas = 100
+)
+
+var (
+	scaledJobGVR = schema.GroupVersionResource{
+		Group:    "keda.sh",
+		Version:  "v1alpha1",
+		Resource: "scaledjobs",
+	}
+	scaledObjectGVR = schema.GroupVersionResource{
+		Group:    "keda.sh",
+		Version:  "v1alpha1",
+		Resource: "scaledobjects",
+	}
+)
+
+func init() {
+	details := func(r types.AsyncResource) []kinds.Section {
+		var fields []kinds.Field
+		if len(r.TriggerNames) > 0 {
+			fields = append(fields, kinds.Field{Label: "Triggers", Value: strings.Join(r.TriggerNames, ", ")})
+		}
+		return append([]kinds.Section{{Fields: fields}}, attributeDetails(
+			attribute{"target", "Target"},
+			attribute{"replicas", "Replicas"},
+			attribute{"active", "Active"},
+			attribute{"jobs", "Jobs"},
+			attribute{"metrics", "Metrics"},
+		)(r)...)
+	}
+
+	kinds.Register(
+		kinds.Kind{
+			Kind:    kindScaledJob,
+			GVR:     scaledJobGVR,
+			View:    types.ViewJobs,
+			Convert: convertUnstructured(scaledJobToResource),
+			Link:    linkScaledJobs,
+			Details: details,
+		},
+		kinds.Kind{
+			Kind:    kindScaledObject,
+			GVR:     scaledObjectGVR,
+			View:    types.ViewEvents,
+			Convert: convertUnstructured(scaledObjectToResource),
+			Columns: []kinds.Column{
+				{Header: "TRIGGER", Width: 35, Value: func(r types.AsyncResource) string { return firstWithCount(r.TriggerNames) }},
+				{Header: "QUEUE", Width: 8, Value: func(r types.AsyncResource) string {
+					if r.Attributes["metrics"] == "" {
+						return ""
+					}
+					return strconv.Itoa(r.QueueDepth)
+				}},
+			},
+			Details: details,
+		},
+	)
+}
+
+func scaledJobToResource(obj unstructured.Unstructured) types.AsyncResource {
+	r := scaledToResource(kindScaledJob, obj)
+	if sa, ok, _ := unstructured.NestedString(obj.Object, "spec", "jobTargetRef", "template", "spec", "serviceAccountName"); ok {
+		r.ServiceAccount = sa
+	}
+	return r
+}
+
+func scaledObjectToResource(obj unstructured.Unstructured) types.AsyncResource {
+	r := scaledToResource(kindScaledObject, obj)
+
+	name, _, _ := unstructured.NestedString(obj.Object, "spec", "scaleTargetRef", "name")
+	kind, _, _ := unstructured.NestedString(obj.Object, "spec", "scaleTargetRef", "kind")
+	if kind == "" {
+		kind = "Deployment"
+	}
+	r.Attributes["target"] = kind + "/" + name
+
+	// Read through the external metrics API by annotateQueueDepth
+	names, _, _ := unstructured.NestedStringSlice(obj.Object, "status", "externalMetricNames")
+	r.Attributes["metricNames"] = strings.Join(names, ",")
+	return r
+}
+
+// scaledToResource reads the fields ScaledJobs and ScaledObjects share
+func scaledToResource(kind types.ResourceKind, obj unstructured.Unstructured) types.AsyncResource {
+	r := types.AsyncResource{
+		UID:        string(obj.GetUID()),
+		Kind:       kind,
+		Name:       obj.GetName(),
+		Namespace:  obj.GetNamespace(),
+		Status:     types.StatusPending,
+		Attributes: make(map[string]string),
+	}
+
+	triggers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "triggers")
+	for _, t := range triggers {
+		if trigger, ok := t.(map[string]interface{}); ok {
+			if typ, ok := trigger["type"].(string); ok {
+				r.TriggerNames = append(r.TriggerNames, typ)
+			}
+		}
+	}
+
+	minReplicas, ok, _ := unstructured.NestedInt64(obj.Object, "spec", "minReplicaCount")
+	if !ok {
+		minReplicas = kedaDefaultMinReplicas
+	}
+	maxReplicas, ok, _ := unstructured.NestedInt64(obj.Object, "spec", "maxReplicaCount")
+	if !ok {
+		maxReplicas = kedaDefaultMaxReplicas
+	}
+	r.Attributes["replicas"] = fmt.Sprintf("min %d, max %d", minReplicas, maxReplicas)
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	paused := false
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch cond["type"] {
+		case "Ready":
+			switch cond["status"] {
+			case "True":
+				r.Status = types.StatusRunning
+			case "False":
+				r.Status = types.StatusFailed
+				r.Message, _ = cond["message"].(string)
+			}
+		case "Active":
+			r.Attributes["active"], _ = cond["status"].(string)
+		case "Paused":
+			paused = cond["status"] == "True"
+		}
+	}
+	if paused {
+		r.Suspended = true
+		r.Status = types.StatusSuspended
+	}
+	return r
+}
+
+// linkScaledJobs nests the Jobs KEDA spawned under their ScaledJob and
+// counts them by state
+func linkScaledJobs(resources []types.AsyncResource) {
+	scaledJobs := make(map[string]int)
+	for i, r := range resources {
+		if r.Kind == kindScaledJob {
+			scaledJobs[r.Namespace+"/"+r.Name] = i
+		}
+	}
+
+	active := make(map[int]int)
+	pending := make(map[int]int)
+	for i, r := range resources {
+		name, ok := strings.CutPrefix(r.Attributes["controller"], string(kindScaledJob)+"/")
+		if r.Kind != types.KindJob || !ok {
+			continue
+		}
+		sj, ok := scaledJobs[r.Namespace+"/"+name]
+		if !ok {
+			continue
+		}
+		resources[i].ParentKind = string(kindScaledJob)
+		resources[i].ParentName = name
+
+		switch r.Status {
+		case types.StatusRunning:
+			active[sj]++
+		case types.StatusPending:
+			pending[sj]++
+		case types.StatusSucceeded:
+			resources[sj].SuccessCount++
+		case types.StatusFailed:
+			resources[sj].FailureCount++
+		}
+	}
+
+	for _, i := range scaledJobs {
+		resources[i].Attributes["jobs"] = fmt.Sprintf("%d active, %d pending", active[i], pending[i])
+	}
+}
+
+// externalMetricList is the part of an external.metrics.k8s.io list flowtop reads
+type externalMetricList struct {
+	Items []struct {
+		Value resource.Quantity `json:"value"`
+	} `json:"items"`
+}
+
+// Limits of the external metric reads, which run in the background so a
+// slow metrics adapter does not hold up the refresh
+const (
+	maxMetricReads = 4
+	metricTimeout  = 5 * time.Second
+	metricMaxAge   = 15 * time.Second
+)
+
+// annotateQueueDepth fills in the external metrics KEDA serves for
+// ScaledObjects from the cache; missing or stale metrics are read in the
+// background and show up on a later refresh
+func (c *Client) annotateQueueDepth(resources []types.AsyncResource) {
+	seen := make(map[string]bool)
+	for i, r := range resources {
+		if r.Kind != kindScaledObject || r.Attributes["metricNames"] == "" {
+			continue
+		}
+		var values []string
+		for _, name := range strings.Split(r.Attributes["metricNames"], ",") {
+			key := r.Namespace + "/" + r.Name + "/" + name
+			seen[key] = true
+			value, ok := c.metrics.get(key, func(ctx context.Context) (int64, error) {
+				return c.externalMetric(ctx, r.Namespace, name, scaledObjectLabel+"="+r.Name)
+			})
+			if !ok {
+				continue
+			}
+			values = append(values, fmt.Sprintf("%s=%d", name, value))
+			resources[i].QueueDepth = max(resources[i].QueueDepth, int(value))
+		}
+		resources[i].Attributes["metrics"] = strings.Join(values, ", ")
+	}
+
+	// Forget the metrics of ScaledObjects that are gone
+	c.metrics.prune(seen)
+}
+
+// externalMetric returns the value of an external metric, summed over
+// the series the selector matches
+func (c *Client) externalMetric(ctx context.Context, namespace, name, selector string) (int64, error) {
+	data, err := c.clientset.Discovery().RESTClient().Get().
+		AbsPath("/apis/external.metrics.k8s.io/v1beta1/namespaces", namespace, name).
+		Param("labelSelector", selector).
+		DoRaw(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var list externalMetricList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return 0, fmt.Errorf("invalid external metric %s: %w", name, err)
+	}
+	var total int64
+	for _, item := range list.Items {
+		total += item.Value.Value()
+	}
+	return total, nil
+}
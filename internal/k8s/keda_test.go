@@ -0,0 +1,200 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ginbear/k8s-flowtop/internal/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func kedaObject(spec, status map[string]interface{}) unstructured.Unstructured {
+	obj := unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	if status != nil {
+		obj.Object["status"] = status
+	}
+	obj.SetName("worker")
+	obj.SetNamespace("default")
+	return obj
+}
+
+func kedaConditions(conditions ...map[string]interface{}) map[string]interface{} {
+	list := make([]interface{}, len(conditions))
+	for i, c := range conditions {
+		list[i] = c
+	}
+	return map[string]interface{}{"conditions": list}
+}
+
+func TestScaledToResource(t *testing.T) {
+	tests := []struct {
+		name         string
+		spec         map[string]interface{}
+		status       map[string]interface{}
+		wantStatus   types.ResourceStatus
+		wantReplicas string
+		wantMessage  string
+		wantActive   string
+		wantTriggers []string
+	}{
+		{
+			name:         "defaults",
+			spec:         map[string]interface{}{},
+			wantStatus:   types.StatusPending,
+			wantReplicas: "min 0, max 100",
+		},
+		{
+			name: "ready and active",
+			spec: map[string]interface{}{
+				"minReplicaCount": int64(1),
+				"maxReplicaCount": int64(10),
+				"triggers": []interface{}{
+					map[string]interface{}{"type": "rabbitmq"},
+					map[string]interface{}{"type": "cron"},
+				},
+			},
+			status: kedaConditions(
+				map[string]interface{}{"type": "Ready", "status": "True"},
+				map[string]interface{}{"type": "Active", "status": "True"},
+			),
+			wantStatus:   types.StatusRunning,
+			wantReplicas: "min 1, max 10",
+			wantActive:   "True",
+			wantTriggers: []string{"rabbitmq", "cron"},
+		},
+		{
+			name: "not ready",
+			spec: map[string]interface{}{},
+			status: kedaConditions(
+				map[string]interface{}{"type": "Ready", "status": "False", "message": "ScaledObject doesn't have correct scaleTargetRef"},
+			),
+			wantStatus:   types.StatusFailed,
+			wantReplicas: "min 0, max 100",
+			wantMessage:  "ScaledObject doesn't have correct scaleTargetRef",
+		},
+		{
+			name: "paused",
+			spec: map[string]interface{}{},
+			status: kedaConditions(
+				map[string]interface{}{"type": "Ready", "status": "True"},
+				map[string]interface{}{"type": "Paused", "status": "True"},
+			),
+			wantStatus:   types.StatusSuspended,
+			wantReplicas: "min 0, max 100",
+		},
+		{
+			name: "unpaused",
+			spec: map[string]interface{}{},
+			status: kedaConditions(
+				map[string]interface{}{"type": "Ready", "status": "True"},
+				map[string]interface{}{"type": "Paused", "status": "False"},
+			),
+			wantStatus:   types.StatusRunning,
+			wantReplicas: "min 0, max 100",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := scaledToResource(kindScaledJob, kedaObject(tt.spec, tt.status))
+			if r.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", r.Status, tt.wantStatus)
+			}
+			if r.Suspended != (tt.wantStatus == types.StatusSuspended) {
+				t.Errorf("Suspended = %v", r.Suspended)
+			}
+			if r.Attributes["replicas"] != tt.wantReplicas {
+				t.Errorf("replicas = %q, want %q", r.Attributes["replicas"], tt.wantReplicas)
+			}
+			if r.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", r.Message, tt.wantMessage)
+			}
+			if r.Attributes["active"] != tt.wantActive {
+				t.Errorf("active = %q, want %q", r.Attributes["active"], tt.wantActive)
+			}
+			if len(r.TriggerNames) != len(tt.wantTriggers) {
+				t.Errorf("TriggerNames = %v, want %v", r.TriggerNames, tt.wantTriggers)
+			}
+		})
+	}
+}
+
+func TestScaledObjectToResource(t *testing.T) {
+	r := scaledObjectToResource(kedaObject(
+		map[string]interface{}{"scaleTargetRef": map[string]interface{}{"name": "api"}},
+		map[string]interface{}{"externalMetricNames": []interface{}{"s0-rabbitmq-jobs", "s1-cron"}},
+	))
+	if r.Attributes["target"] != "Deployment/api" {
+		t.Errorf("target = %q, want Deployment/api", r.Attributes["target"])
+	}
+	if r.Attributes["metricNames"] != "s0-rabbitmq-jobs,s1-cron" {
+		t.Errorf("metricNames = %q", r.Attributes["metricNames"])
+	}
+}
+
+func TestLinkScaledJobs(t *testing.T) {
+	job := func(name, namespace, controller string, status types.ResourceStatus) types.AsyncResource {
+		r := types.AsyncResource{Kind: types.KindJob, Name: name, Namespace: namespace, Status: status}
+		if controller != "" {
+			setAttribute(&r, "controller", controller)
+		}
+		return r
+	}
+	resources := []types.AsyncResource{
+		{Kind: kindScaledJob, Name: "worker", Namespace: "default", Attributes: map[string]string{}},
+		{Kind: kindScaledJob, Name: "idle", Namespace: "default", Attributes: map[string]string{}},
+		job("worker-1", "default", "ScaledJob/worker", types.StatusRunning),
+		job("worker-2", "default", "ScaledJob/worker", types.StatusRunning),
+		job("worker-3", "default", "ScaledJob/worker", types.StatusPending),
+		job("worker-4", "default", "ScaledJob/worker", types.StatusSucceeded),
+		job("worker-5", "default", "ScaledJob/worker", types.StatusFailed),
+		job("other-ns", "staging", "ScaledJob/worker", types.StatusRunning),
+		job("unknown", "default", "ScaledJob/missing", types.StatusRunning),
+		job("plain", "default", "", types.StatusRunning),
+	}
+	linkScaledJobs(resources)
+
+	worker, idle := resources[0], resources[1]
+	if worker.Attributes["jobs"] != "2 active, 1 pending" {
+		t.Errorf("worker jobs = %q", worker.Attributes["jobs"])
+	}
+	if worker.SuccessCount != 1 || worker.FailureCount != 1 {
+		t.Errorf("worker counts = %d succeeded, %d failed, want 1, 1", worker.SuccessCount, worker.FailureCount)
+	}
+	if idle.Attributes["jobs"] != "0 active, 0 pending" {
+		t.Errorf("idle jobs = %q", idle.Attributes["jobs"])
+	}
+
+	for _, r := range resources[2:] {
+		linked := r.Namespace == "default" && r.Attributes["controller"] == "ScaledJob/worker"
+		if linked != (r.ParentName == "worker" && r.ParentKind == string(kindScaledJob)) {
+			t.Errorf("%s/%s parent = %s/%s", r.Namespace, r.Name, r.ParentKind, r.ParentName)
+		}
+	}
+}
+
+func TestAnnotateQueueDepth(t *testing.T) {
+	c := &Client{metrics: newReadCache[int64](time.Hour, time.Second, 1)}
+	fresh := time.Now()
+	c.metrics.values["default/worker/s0-queue"] = cachedRead[int64]{value: 12, ok: true, read: fresh}
+	c.metrics.values["default/worker/s1-lag"] = cachedRead[int64]{value: 40, ok: true, read: fresh}
+	c.metrics.values["default/deleted/s0-queue"] = cachedRead[int64]{value: 1, ok: true, read: fresh}
+
+	resources := []types.AsyncResource{
+		{Kind: kindScaledObject, Name: "worker", Namespace: "default", Attributes: map[string]string{"metricNames": "s0-queue,s1-lag"}},
+		{Kind: kindScaledObject, Name: "no-metrics", Namespace: "default", Attributes: map[string]string{}},
+	}
+	c.annotateQueueDepth(resources)
+
+	if got := resources[0].Attributes["metrics"]; got != "s0-queue=12, s1-lag=40" {
+		t.Errorf("metrics = %q", got)
+	}
+	if resources[0].QueueDepth != 40 {
+		t.Errorf("QueueDepth = %d, want the largest metric", resources[0].QueueDepth)
+	}
+	if resources[1].Attributes["metrics"] != "" || resources[1].QueueDepth != 0 {
+		t.Errorf("ScaledObject without metrics = %+v", resources[1])
+	}
+	if _, ok := c.metrics.values["default/deleted/s0-queue"]; ok {
+		t.Error("metric of a deleted ScaledObject was kept")
+	}
+}
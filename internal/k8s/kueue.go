@@ -0,0 +1,373 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ginbear/k8s-flowtop/internal/kinds"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Kueue kinds; queues are shown in the queue overview
+const (
+	kindWorkload     types.ResourceKind = "Workload"
+	KindLocalQueue   types.ResourceKind = "LocalQueue"
+	KindClusterQueue types.ResourceKind = "ClusterQueue"
+)
+
+// Admission states of a Workload, as shown on its Job
+const (
+	admissionPending       = "Pending"
+	admissionQuotaReserved = "QuotaReserved"
+	admissionAdmitted      = "Admitted"
+	admissionEvicted       = "Evicted"
+	admissionFinished      = "Finished"
+)
+
+var (
+	workloadGVR = schema.GroupVersionResource{
+		Group:    "kueue.x-k8s.io",
+		Version:  "v1beta1",
+		Resource: "workloads",
+	}
+	localQueueGVR = schema.GroupVersionResource{
+		Group:    "kueue.x-k8s.io",
+		Version:  "v1beta1",
+		Resource: "localqueues",
+	}
+	clusterQueueGVR = schema.GroupVersionResource{
+		Group:    "kueue.x-k8s.io",
+		Version:  "v1beta1",
+		Resource: "clusterqueues",
+	}
+)
+
+// kueueDetails shows the admission of a Job queued through Kueue
+func kueueDetails(r types.AsyncResource) []kinds.Section {
+	sections := attributeDetails(
+		attribute{"admission", "Admission"},
+		attribute{"queue", "Queue"},
+		attribute{"clusterQueue", "ClusterQueue"},
+		attribute{"position", "Position"},
+		attribute{"quotaReason", "Reason"},
+	)(r)
+	sections[0].Title = "⏳ Kueue"
+	return sections
+}
+
+func init() {
+	queueDetails := attributeDetails(
+		attribute{"clusterQueue", "ClusterQueue"},
+		attribute{"cohort", "Cohort"},
+		attribute{"pending", "Pending"},
+		attribute{"reserving", "Reserving"},
+		attribute{"admitted", "Admitted"},
+		attribute{"usage", "Usage"},
+	)
+
+	kinds.Register(
+		kinds.Kind{
+			Kind:    kindWorkload,
+			GVR:     workloadGVR,
+			Hidden:  true,
+			Convert: convertUnstructured(workloadToResource),
+			Link:    linkWorkloads,
+		},
+		kinds.Kind{
+			Kind:    KindLocalQueue,
+			GVR:     localQueueGVR,
+			View:    types.ViewQueues,
+			Convert: convertUnstructured(localQueueToResource),
+			Details: queueDetails,
+		},
+		kinds.Kind{
+			Kind:          KindClusterQueue,
+			GVR:           clusterQueueGVR,
+			View:          types.ViewQueues,
+			ClusterScoped: true,
+			Convert:       convertUnstructured(clusterQueueToResource),
+			Details:       queueDetails,
+		},
+	)
+}
+
+func workloadToResource(obj unstructured.Unstructured) types.AsyncResource {
+	r := types.AsyncResource{
+		UID:        string(obj.GetUID()),
+		Kind:       kindWorkload,
+		Name:       obj.GetName(),
+		Namespace:  obj.GetNamespace(),
+		Status:     types.StatusPending,
+		Attributes: make(map[string]string),
+	}
+
+	r.Attributes["queue"], _, _ = unstructured.NestedString(obj.Object, "spec", "queueName")
+	r.Attributes["clusterQueue"], _, _ = unstructured.NestedString(obj.Object, "status", "admission", "clusterQueue")
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Controller != nil && *ref.Controller {
+			r.Attributes["owner"] = ref.Kind + "/" + ref.Name
+		}
+	}
+
+	conditions := make(map[string]map[string]interface{})
+	list, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range list {
+		if cond, ok := c.(map[string]interface{}); ok {
+			if typ, ok := cond["type"].(string); ok {
+				conditions[typ] = cond
+			}
+		}
+	}
+	isTrue := func(typ string) bool {
+		return conditions[typ] != nil && conditions[typ]["status"] == "True"
+	}
+
+	admission := admissionPending
+	switch {
+	case isTrue("Finished"):
+		admission = admissionFinished
+		r.Status = types.StatusSucceeded
+	case isTrue("Admitted"):
+		admission = admissionAdmitted
+		r.Status = types.StatusRunning
+	case isTrue("Evicted"):
+		// Evicted workloads are requeued; the eviction explains why
+		admission = admissionEvicted
+		r.Attributes["quotaReason"], _ = conditions["Evicted"]["message"].(string)
+	case isTrue("QuotaReserved"):
+		// Quota is reserved, admission checks are pending
+		admission = admissionQuotaReserved
+	default:
+		if cond := conditions["QuotaReserved"]; cond != nil {
+			r.Attributes["quotaReason"], _ = cond["message"].(string)
+		}
+	}
+	r.Attributes["admission"] = admission
+	return r
+}
+
+// linkWorkloads shows the admission of each Workload on the Job it
+// belongs to
+func linkWorkloads(resources []types.AsyncResource) {
+	workloads := make(map[string]types.AsyncResource)
+	for _, r := range resources {
+		if r.Kind == kindWorkload && strings.HasPrefix(r.Attributes["owner"], string(types.KindJob)+"/") {
+			workloads[r.Namespace+"/"+strings.TrimPrefix(r.Attributes["owner"], string(types.KindJob)+"/")] = r
+		}
+	}
+
+	for i, r := range resources {
+		if r.Kind != types.KindJob {
+			continue
+		}
+		wl, ok := workloads[r.Namespace+"/"+r.Name]
+		if !ok {
+			continue
+		}
+		setAttribute(&resources[i], "workload", wl.Name)
+		for _, key := range []string{"admission", "queue", "clusterQueue", "quotaReason"} {
+			setAttribute(&resources[i], key, wl.Attributes[key])
+		}
+	}
+}
+
+// pendingWorkloadList is the part of a Kueue visibility pendingworkloads
+// list flowtop reads
+type pendingWorkloadList struct {
+	Items []struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		PositionInClusterQueue int `json:"positionInClusterQueue"`
+		PositionInLocalQueue   int `json:"positionInLocalQueue"`
+	} `json:"items"`
+}
+
+// Limits of the queue position reads, which run in the background so a
+// slow or missing visibility API does not hold up the refresh
+const (
+	maxPositionReads = 4
+	positionTimeout  = 5 * time.Second
+	positionMaxAge   = 15 * time.Second
+)
+
+// annotateAdmission explains Jobs that wait for admission, with their
+// position read from the Kueue visibility API when it is served. Positions
+// are cached per LocalQueue and show up on a later refresh.
+func (c *Client) annotateAdmission(resources []types.AsyncResource) {
+	seen := make(map[string]bool)
+	for i, r := range resources {
+		admission := r.Attributes["admission"]
+		if r.Kind != types.KindJob || (admission != admissionPending && admission != admissionEvicted) {
+			continue
+		}
+
+		queue := r.Attributes["queue"]
+		msg := fmt.Sprintf("queued in %s", queue)
+		if queue != "" {
+			key := r.Namespace + "/" + queue
+			seen[key] = true
+			positions, _ := c.positions.get(key, func(ctx context.Context) (map[string]queuePosition, error) {
+				return c.pendingPositions(ctx, r.Namespace, queue)
+			})
+			if p, ok := positions[r.Attributes["workload"]]; ok {
+				resources[i].Attributes["position"] = fmt.Sprintf("#%d in LocalQueue, #%d in ClusterQueue", p.local, p.cluster)
+				msg += fmt.Sprintf(" at #%d", p.local)
+			}
+		}
+		if reason := r.Attributes["quotaReason"]; reason != "" {
+			msg += ": " + reason
+		}
+		resources[i].Message = msg
+	}
+
+	// Forget the positions of queues no Job waits in
+	c.positions.prune(seen)
+}
+
+// queuePosition is the 1-based position of a pending workload
+type queuePosition struct {
+	local   int
+	cluster int
+}
+
+// pendingPositions returns the queue positions of the pending workloads
+// of a LocalQueue
+func (c *Client) pendingPositions(ctx context.Context, namespace, queue string) (map[string]queuePosition, error) {
+	data, err := c.clientset.Discovery().RESTClient().Get().
+		AbsPath("/apis/visibility.kueue.x-k8s.io/v1beta1/namespaces", namespace, "localqueues", queue, "pendingworkloads").
+		DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var list pendingWorkloadList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("invalid pending workloads of %s: %w", queue, err)
+	}
+
+	positions := make(map[string]queuePosition)
+	for _, item := range list.Items {
+		positions[item.Metadata.Name] = queuePosition{
+			local:   item.PositionInLocalQueue + 1,
+			cluster: item.PositionInClusterQueue + 1,
+		}
+	}
+	return positions, nil
+}
+
+func localQueueToResource(obj unstructured.Unstructured) types.AsyncResource {
+	r := queueToResource(KindLocalQueue, obj)
+	r.Attributes["clusterQueue"], _, _ = unstructured.NestedString(obj.Object, "spec", "clusterQueue")
+
+	usage, _, _ := unstructured.NestedSlice(obj.Object, "status", "flavorUsage")
+	r.Attributes["usage"] = flavorUsage(usage, nil)
+	return r
+}
+
+func clusterQueueToResource(obj unstructured.Unstructured) types.AsyncResource {
+	r := queueToResource(KindClusterQueue, obj)
+
+	if cohort, ok, _ := unstructured.NestedString(obj.Object, "spec", "cohort"); ok {
+		r.Attributes["cohort"] = cohort
+	} else {
+		r.Attributes["cohort"], _, _ = unstructured.NestedString(obj.Object, "spec", "cohortName")
+	}
+
+	if policy, _, _ := unstructured.NestedString(obj.Object, "spec", "stopPolicy"); policy == "Hold" || policy == "HoldAndDrain" {
+		r.Suspended = true
+		r.Status = types.StatusSuspended
+	}
+
+	// Nominal quota per "flavor/resource"
+	quota := make(map[string]string)
+	groups, _, _ := unstructured.NestedSlice(obj.Object, "spec", "resourceGroups")
+	for _, g := range groups {
+		group, _ := g.(map[string]interface{})
+		flavors, _, _ := unstructured.NestedSlice(group, "flavors")
+		for _, f := range flavors {
+			flavor, _ := f.(map[string]interface{})
+			resources, _, _ := unstructured.NestedSlice(flavor, "resources")
+			for _, res := range resources {
+				res, _ := res.(map[string]interface{})
+				if nominal, ok := res["nominalQuota"]; ok && nominal != nil {
+					quota[fmt.Sprint(flavor["name"], "/", res["name"])] = fmt.Sprint(nominal)
+				}
+			}
+		}
+	}
+	usage, _, _ := unstructured.NestedSlice(obj.Object, "status", "flavorsUsage")
+	r.Attributes["usage"] = flavorUsage(usage, quota)
+	return r
+}
+
+// queueToResource reads the fields LocalQueues and ClusterQueues share
+func queueToResource(kind types.ResourceKind, obj unstructured.Unstructured) types.AsyncResource {
+	r := types.AsyncResource{
+		UID:        string(obj.GetUID()),
+		Kind:       kind,
+		Name:       obj.GetName(),
+		Namespace:  obj.GetNamespace(),
+		Status:     types.StatusPending,
+		Attributes: make(map[string]string),
+	}
+
+	for _, field := range []string{"pending", "reserving", "admitted"} {
+		count, _, _ := unstructured.NestedInt64(obj.Object, "status", field+"Workloads")
+		r.Attributes[field] = fmt.Sprint(count)
+	}
+	r.Message = fmt.Sprintf("%s pending, %s admitted", r.Attributes["pending"], r.Attributes["admitted"])
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		if cond, ok := c.(map[string]interface{}); ok && cond["type"] == "Active" {
+			if cond["status"] == "True" {
+				r.Status = types.StatusRunning
+			} else {
+				r.Status = types.StatusFailed
+				r.Message, _ = cond["message"].(string)
+			}
+		}
+	}
+	return r
+}
+
+// flavorUsage formats the usage of each flavor's resources, with the
+// share of the quota when known, e.g. "default: cpu 6/8 (75%), memory 12Gi"
+func flavorUsage(flavors []interface{}, quota map[string]string) string {
+	var parts []string
+	for _, f := range flavors {
+		flavor, ok := f.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var usage []string
+		resources, _, _ := unstructured.NestedSlice(flavor, "resources")
+		for _, res := range resources {
+			res, ok := res.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			total := fmt.Sprint(res["total"])
+			s := fmt.Sprintf("%v %s", res["name"], total)
+			if nominal, ok := quota[fmt.Sprint(flavor["name"], "/", res["name"])]; ok {
+				s += "/" + nominal
+				used, err1 := resource.ParseQuantity(total)
+				limit, err2 := resource.ParseQuantity(nominal)
+				if err1 == nil && err2 == nil && !limit.IsZero() {
+					s += fmt.Sprintf(" (%d%%)", used.MilliValue()*100/limit.MilliValue())
+				}
+			}
+			usage = append(usage, s)
+		}
+		if len(usage) > 0 {
+			parts = append(parts, fmt.Sprintf("%v: %s", flavor["name"], strings.Join(usage, ", ")))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
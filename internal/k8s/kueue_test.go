@@ -0,0 +1,145 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ginbear/k8s-flowtop/internal/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestFlavorUsage(t *testing.T) {
+	usage := func(flavor string, resources ...map[string]interface{}) interface{} {
+		rs := make([]interface{}, len(resources))
+		for i, r := range resources {
+			rs[i] = r
+		}
+		return map[string]interface{}{"name": flavor, "resources": rs}
+	}
+
+	tests := []struct {
+		name    string
+		flavors []interface{}
+		quota   map[string]string
+		want    string
+	}{
+		{
+			name: "no usage",
+		},
+		{
+			name: "with quota",
+			flavors: []interface{}{usage("default",
+				map[string]interface{}{"name": "cpu", "total": "3"},
+				map[string]interface{}{"name": "memory", "total": "2Gi"},
+			)},
+			quota: map[string]string{"default/cpu": "4", "default/memory": "8Gi"},
+			want:  "default: cpu 3/4 (75%), memory 2Gi/8Gi (25%)",
+		},
+		{
+			name:    "without quota",
+			flavors: []interface{}{usage("spot", map[string]interface{}{"name": "cpu", "total": "500m"})},
+			want:    "spot: cpu 500m",
+		},
+		{
+			name:    "zero quota",
+			flavors: []interface{}{usage("default", map[string]interface{}{"name": "nvidia.com/gpu", "total": "0"})},
+			quota:   map[string]string{"default/nvidia.com/gpu": "0"},
+			want:    "default: nvidia.com/gpu 0/0",
+		},
+		{
+			name: "several flavors",
+			flavors: []interface{}{
+				usage("on-demand", map[string]interface{}{"name": "cpu", "total": "1"}),
+				usage("empty"),
+				usage("spot", map[string]interface{}{"name": "cpu", "total": "2"}),
+			},
+			quota: map[string]string{"on-demand/cpu": "2"},
+			want:  "on-demand: cpu 1/2 (50%); spot: cpu 2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := flavorUsage(tt.flavors, tt.quota); got != tt.want {
+				t.Errorf("flavorUsage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterQueueUsage(t *testing.T) {
+	resources := func(rs ...interface{}) map[string]interface{} {
+		return map[string]interface{}{"name": "default", "resources": rs}
+	}
+	obj := unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "team-a"},
+		"spec": map[string]interface{}{
+			"resourceGroups": []interface{}{
+				map[string]interface{}{"flavors": []interface{}{resources(
+					map[string]interface{}{"name": "cpu", "nominalQuota": int64(8)},
+					map[string]interface{}{"name": "memory"},
+				)}},
+			},
+		},
+		"status": map[string]interface{}{
+			"flavorsUsage": []interface{}{resources(
+				map[string]interface{}{"name": "cpu", "total": "2"},
+				map[string]interface{}{"name": "memory", "total": "1Gi"},
+			)},
+		},
+	}}
+
+	want := "default: cpu 2/8 (25%), memory 1Gi"
+	if got := clusterQueueToResource(obj).Attributes["usage"]; got != want {
+		t.Errorf("usage = %q, want %q", got, want)
+	}
+}
+
+func TestAnnotateAdmission(t *testing.T) {
+	c := &Client{positions: newReadCache[map[string]queuePosition](time.Hour, time.Second, 1)}
+	c.positions.values["default/batch"] = cachedRead[map[string]queuePosition]{
+		value: map[string]queuePosition{"job-first-1a2b3": {local: 3, cluster: 7}},
+		ok:    true,
+		read:  time.Now(),
+	}
+	c.positions.values["default/idle"] = cachedRead[map[string]queuePosition]{ok: true, read: time.Now()}
+
+	job := func(name string, attributes map[string]string) types.AsyncResource {
+		return types.AsyncResource{Kind: types.KindJob, Name: name, Namespace: "default", Message: "unchanged", Attributes: attributes}
+	}
+	resources := []types.AsyncResource{
+		job("first", map[string]string{
+			"admission": admissionPending, "queue": "batch", "workload": "job-first-1a2b3",
+			"quotaReason": "couldn't assign flavors to pod set main: insufficient quota for cpu",
+		}),
+		job("unlisted", map[string]string{"admission": admissionPending, "queue": "batch", "workload": "job-unlisted"}),
+		job("evicted", map[string]string{"admission": admissionEvicted, "queue": "batch", "workload": "job-first-1a2b3"}),
+		job("admitted", map[string]string{"admission": "Admitted", "queue": "batch", "workload": "job-first-1a2b3"}),
+		{Kind: kindWorkload, Name: "wl", Namespace: "default", Attributes: map[string]string{"admission": admissionPending}},
+	}
+	c.annotateAdmission(resources)
+
+	tests := []struct {
+		want         string
+		wantPosition string
+	}{
+		{"queued in batch at #3: couldn't assign flavors to pod set main: insufficient quota for cpu", "#3 in LocalQueue, #7 in ClusterQueue"},
+		{"queued in batch", ""},
+		{"queued in batch at #3", "#3 in LocalQueue, #7 in ClusterQueue"},
+		{"unchanged", ""},
+		{"", ""},
+	}
+	for i, tt := range tests {
+		r := resources[i]
+		if r.Message != tt.want {
+			t.Errorf("%s message = %q, want %q", r.Name, r.Message, tt.want)
+		}
+		if r.Attributes["position"] != tt.wantPosition {
+			t.Errorf("%s position = %q, want %q", r.Name, r.Attributes["position"], tt.wantPosition)
+		}
+	}
+
+	// Queues no Job waits in are forgotten
+	if _, ok := c.positions.values["default/idle"]; ok {
+		t.Error("positions of an idle queue were kept")
+	}
+}
@@ -0,0 +1,199 @@
+package k8s
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ginbear/k8s-flowtop/internal/config"
+	"github.com/ginbear/k8s-flowtop/internal/kinds"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// mappingTabs maps the tab names of kind mappings to views
+var mappingTabs = map[string]types.ViewMode{
+	"":          types.ViewAll,
+	"all":       types.ViewAll,
+	"jobs":      types.ViewJobs,
+	"workflows": types.ViewWorkflows,
+	"events":    types.ViewEvents,
+}
+
+var statuses = []types.ResourceStatus{
+	types.StatusRunning,
+	types.StatusSucceeded,
+	types.StatusFailed,
+	types.StatusPending,
+	types.StatusSuspended,
+	types.StatusUnknown,
+}
+
+// mappedKind converts objects of a kind declared in the config
+type mappedKind struct {
+	kind       types.ResourceKind
+	phaseMap   map[string]types.ResourceStatus
+	parentKind string
+
+	phase, startTime, endTime, lastRun *jsonpath.JSONPath
+	schedule, timezone, message        *jsonpath.JSONPath
+	serviceAccount, parent             *jsonpath.JSONPath
+}
+
+// RegisterKinds registers the kinds declared in the config; it must be
+// called before creating a client so that discovery checks them
+func RegisterKinds(mappings []config.KindMapping) error {
+	var ks []kinds.Kind
+	seen := make(map[string]bool)
+	for _, km := range mappings {
+		if _, builtin := kinds.Lookup(types.ResourceKind(km.Kind)); builtin || seen[km.Kind] {
+			return fmt.Errorf("kind %q is already defined", km.Kind)
+		}
+		seen[km.Kind] = true
+
+		k, err := compileMapping(km)
+		if err != nil {
+			return fmt.Errorf("kind %q: %w", km.Kind, err)
+		}
+		ks = append(ks, k)
+	}
+	kinds.Register(ks...)
+	return nil
+}
+
+// compileMapping parses the expressions of a kind mapping
+func compileMapping(km config.KindMapping) (kinds.Kind, error) {
+	if km.Kind == "" || km.Version == "" || km.Resource == "" {
+		return kinds.Kind{}, errors.New("kind, version and resource are required")
+	}
+	view, ok := mappingTabs[strings.ToLower(km.Tab)]
+	if !ok {
+		return kinds.Kind{}, fmt.Errorf("unknown tab %q (want jobs, workflows or events)", km.Tab)
+	}
+
+	m := &mappedKind{
+		kind:       types.ResourceKind(km.Kind),
+		phaseMap:   make(map[string]types.ResourceStatus),
+		parentKind: km.ParentKind,
+	}
+	for phase, name := range km.PhaseMap {
+		status, ok := parseStatus(name)
+		if !ok {
+			return kinds.Kind{}, fmt.Errorf("phaseMap: unknown status %q", name)
+		}
+		m.phaseMap[phase] = status
+	}
+
+	paths := []struct {
+		name string
+		expr string
+		dst  **jsonpath.JSONPath
+	}{
+		{"phase", km.Phase, &m.phase},
+		{"startTime", km.StartTime, &m.startTime},
+		{"endTime", km.EndTime, &m.endTime},
+		{"lastRun", km.LastRun, &m.lastRun},
+		{"schedule", km.Schedule, &m.schedule},
+		{"timezone", km.Timezone, &m.timezone},
+		{"message", km.Message, &m.message},
+		{"serviceAccount", km.ServiceAccount, &m.serviceAccount},
+		{"parent", km.Parent, &m.parent},
+	}
+	for _, p := range paths {
+		if p.expr == "" {
+			continue
+		}
+		expr := p.expr
+		if !strings.Contains(expr, "{") {
+			expr = "{" + expr + "}"
+		}
+		j := jsonpath.New(p.name).AllowMissingKeys(true)
+		if err := j.Parse(expr); err != nil {
+			return kinds.Kind{}, fmt.Errorf("%s: %w", p.name, err)
+		}
+		*p.dst = j
+	}
+
+	return kinds.Kind{
+		Kind:          m.kind,
+		GVR:           schema.GroupVersionResource{Group: km.Group, Version: km.Version, Resource: km.Resource},
+		View:          view,
+		ClusterScoped: km.ClusterScoped,
+		Convert:       m.convert,
+	}, nil
+}
+
+// parseStatus returns the status a name stands for, ignoring case
+func parseStatus(name string) (types.ResourceStatus, bool) {
+	for _, s := range statuses {
+		if strings.EqualFold(name, string(s)) {
+			return s, true
+		}
+	}
+	return types.StatusUnknown, false
+}
+
+func (m *mappedKind) convert(obj unstructured.Unstructured) (types.AsyncResource, error) {
+	r := types.AsyncResource{
+		UID:            string(obj.GetUID()),
+		Kind:           m.kind,
+		Name:           obj.GetName(),
+		Namespace:      obj.GetNamespace(),
+		Schedule:       evalPath(m.schedule, obj),
+		Timezone:       evalPath(m.timezone, obj),
+		Message:        evalPath(m.message, obj),
+		ServiceAccount: evalPath(m.serviceAccount, obj),
+		StartTime:      evalTime(m.startTime, obj),
+		EndTime:        evalTime(m.endTime, obj),
+		LastRun:        evalTime(m.lastRun, obj),
+	}
+
+	phase := evalPath(m.phase, obj)
+	if status, ok := m.phaseMap[phase]; ok {
+		r.Status = status
+	} else {
+		r.Status, _ = parseStatus(phase)
+	}
+	r.Suspended = r.Status == types.StatusSuspended
+
+	if r.StartTime != nil {
+		if r.EndTime != nil {
+			r.Duration = r.EndTime.Sub(*r.StartTime)
+		} else {
+			r.Duration = time.Since(*r.StartTime)
+		}
+	}
+
+	// A filter may match several owners; nest under the first
+	if names := strings.Fields(evalPath(m.parent, obj)); len(names) > 0 {
+		r.ParentKind = m.parentKind
+		r.ParentName = names[0]
+	}
+	return r, nil
+}
+
+// evalPath returns the text a JSONPath yields for an object, or "" if
+// the path is unset or fails
+func evalPath(j *jsonpath.JSONPath, obj unstructured.Unstructured) string {
+	if j == nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := j.Execute(&buf, obj.Object); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// evalTime parses the RFC 3339 timestamp a JSONPath yields, if any
+func evalTime(j *jsonpath.JSONPath, obj unstructured.Unstructured) *time.Time {
+	t, err := time.Parse(time.RFC3339, evalPath(j, obj))
+	if err != nil {
+		return nil
+	}
+	return &t
+}
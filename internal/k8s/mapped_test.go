@@ -0,0 +1,127 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ginbear/k8s-flowtop/internal/config"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestMappedConvert(t *testing.T) {
+	k, err := compileMapping(config.KindMapping{
+		Kind:       "Backup",
+		Group:      "example.com",
+		Version:    "v1",
+		Resource:   "backups",
+		Tab:        "Jobs",
+		Phase:      ".status.state",
+		PhaseMap:   map[string]string{"InProgress": "running", "Done": "Succeeded"},
+		StartTime:  ".status.startedAt",
+		EndTime:    "{.status.finishedAt}",
+		Message:    ".status.message",
+		Parent:     `{.metadata.ownerReferences[?(@.kind=="Schedule")].name}`,
+		ParentKind: "Schedule",
+	})
+	if err != nil {
+		t.Fatalf("compileMapping() error = %v", err)
+	}
+	if k.View != types.ViewJobs {
+		t.Errorf("View = %v, want Jobs", k.View)
+	}
+
+	object := func(status map[string]interface{}) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":      "nightly-1",
+				"namespace": "backups",
+				"ownerReferences": []interface{}{
+					map[string]interface{}{"kind": "Other", "name": "x"},
+					map[string]interface{}{"kind": "Schedule", "name": "nightly"},
+				},
+			},
+			"status": status,
+		}}
+	}
+
+	tests := []struct {
+		name         string
+		status       map[string]interface{}
+		wantStatus   types.ResourceStatus
+		wantMessage  string
+		wantDuration time.Duration
+	}{
+		{
+			name: "mapped phase",
+			status: map[string]interface{}{
+				"state":      "Done",
+				"startedAt":  "2026-01-01T00:00:00Z",
+				"finishedAt": "2026-01-01T00:05:00Z",
+				"message":    "  all done ",
+			},
+			wantStatus:   types.StatusSucceeded,
+			wantMessage:  "all done",
+			wantDuration: 5 * time.Minute,
+		},
+		{
+			name:       "phase naming a status",
+			status:     map[string]interface{}{"state": "failed"},
+			wantStatus: types.StatusFailed,
+		},
+		{
+			name:       "unknown phase",
+			status:     map[string]interface{}{"state": "Exploded"},
+			wantStatus: types.StatusUnknown,
+		},
+		{
+			name:       "missing fields",
+			wantStatus: types.StatusUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := k.Convert(object(tt.status))
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			if r.Kind != "Backup" || r.Name != "nightly-1" || r.Namespace != "backups" {
+				t.Errorf("identity = %s %s/%s", r.Kind, r.Namespace, r.Name)
+			}
+			if r.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", r.Status, tt.wantStatus)
+			}
+			if r.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", r.Message, tt.wantMessage)
+			}
+			if tt.wantDuration != 0 && r.Duration != tt.wantDuration {
+				t.Errorf("Duration = %v, want %v", r.Duration, tt.wantDuration)
+			}
+			if r.ParentKind != "Schedule" || r.ParentName != "nightly" {
+				t.Errorf("parent = %s/%s, want Schedule/nightly", r.ParentKind, r.ParentName)
+			}
+		})
+	}
+}
+
+func TestCompileMappingErrors(t *testing.T) {
+	base := config.KindMapping{Kind: "Backup", Version: "v1", Resource: "backups"}
+	tests := []struct {
+		name   string
+		modify func(*config.KindMapping)
+	}{
+		{"missing resource", func(km *config.KindMapping) { km.Resource = "" }},
+		{"unknown tab", func(km *config.KindMapping) { km.Tab = "queues" }},
+		{"unknown status", func(km *config.KindMapping) { km.PhaseMap = map[string]string{"Done": "Finished"} }},
+		{"invalid path", func(km *config.KindMapping) { km.Phase = "{.status[" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			km := base
+			tt.modify(&km)
+			if _, err := compileMapping(km); err == nil {
+				t.Error("compileMapping() succeeded, want an error")
+			}
+		})
+	}
+}
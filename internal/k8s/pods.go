@@ -0,0 +1,262 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ginbear/k8s-flowtop/internal/kinds"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// Labels and annotations used to find the pods of a resource
+const (
+	workflowLabel        = "workflows.argoproj.io/workflow"
+	nodeNameAnnotation   = "workflows.argoproj.io/node-name"
+	sensorNameLabel      = "sensor-name"
+	eventSourceNameLabel = "eventsource-name"
+	jobNameLabel         = "job-name"
+)
+
+// reasonPriority ranks failure reasons; the highest one explains a Job best
+var reasonPriority = map[string]int{
+	"OOMKilled":                  6,
+	"ImagePullBackOff":           5,
+	"ErrImagePull":               5,
+	"InvalidImageName":           5,
+	"CreateContainerConfigError": 4,
+	"CreateContainerError":       4,
+	"CrashLoopBackOff":           3,
+	"Evicted":                    3,
+	"DeadlineExceeded":           3,
+	"Unschedulable":              3,
+	"Error":                      2,
+	"ContainerCannotRun":         2,
+}
+
+// Waiting reasons that are part of a normal start
+var benignReasons = map[string]bool{
+	"ContainerCreating": true,
+	"PodInitializing":   true,
+	"Completed":         true,
+}
+
+// LogOptions controls which logs StreamLogs returns
+type LogOptions struct {
+	Container string
+	Follow    bool
+	Previous  bool
+	TailLines int64
+}
+
+// ListPods returns the pods behind a resource, newest first
+func (c *Client) ListPods(ctx context.Context, r types.AsyncResource) ([]types.PodInfo, error) {
+	var selector string
+	switch r.Kind {
+	case types.KindJob:
+		job, err := c.clientset.BatchV1().Jobs(r.Namespace).Get(ctx, r.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		if job.Spec.Selector == nil {
+			return nil, nil
+		}
+		sel, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid job selector: %w", err)
+		}
+		selector = sel.String()
+	default:
+		k, ok := kinds.Lookup(r.Kind)
+		if !ok || k.PodLabel == "" {
+			return nil, nil
+		}
+		selector = k.PodLabel + "=" + r.Name
+	}
+
+	pods, err := c.clientset.CoreV1().Pods(r.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+
+	// Map Argo pod names back to DAG node names
+	nodeNames := make(map[string]string)
+	for _, n := range r.DAGNodes {
+		if n.PodName != "" {
+			nodeNames[n.PodName] = n.Name
+		}
+	}
+
+	result := make([]types.PodInfo, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		info := podToInfo(pod)
+		if name, ok := nodeNames[pod.Name]; ok {
+			info.NodeName = name
+		} else if name, ok := pod.Annotations[nodeNameAnnotation]; ok {
+			info.NodeName = strings.TrimPrefix(name, r.Name+".")
+		}
+		result = append(result, info)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// StreamLogs opens a log stream for a container of a pod
+func (c *Client) StreamLogs(ctx context.Context, namespace, pod string, opts LogOptions) (io.ReadCloser, error) {
+	podOpts := &corev1.PodLogOptions{
+		Container: opts.Container,
+		Follow:    opts.Follow,
+		Previous:  opts.Previous,
+	}
+	if opts.TailLines > 0 {
+		podOpts.TailLines = &opts.TailLines
+	}
+	return c.clientset.CoreV1().Pods(namespace).GetLogs(pod, podOpts).Stream(ctx)
+}
+
+func podToInfo(pod corev1.Pod) types.PodInfo {
+	info := types.PodInfo{
+		Name:      pod.Name,
+		Namespace: pod.Namespace,
+		CreatedAt: pod.CreationTimestamp.Time,
+	}
+	for _, c := range pod.Spec.InitContainers {
+		info.Containers = append(info.Containers, c.Name)
+	}
+	for _, c := range pod.Spec.Containers {
+		info.Containers = append(info.Containers, c.Name)
+	}
+
+	info.Phase = string(pod.Status.Phase)
+	info.Reason = pod.Status.Reason
+	info.HostNodeName = pod.Spec.NodeName
+	if info.Reason == "" {
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse {
+				info.Reason = cond.Reason
+			}
+		}
+	}
+	for _, cs := range pod.Status.InitContainerStatuses {
+		info.Statuses = append(info.Statuses, containerStatus(cs, true))
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		info.Statuses = append(info.Statuses, containerStatus(cs, false))
+	}
+	for _, cs := range info.Statuses {
+		info.Restarts += cs.Restarts
+	}
+
+	// Argo runs the user's step in "main"; otherwise honor the kubectl default
+	if name, ok := pod.Annotations["kubectl.kubernetes.io/default-container"]; ok {
+		info.Default = name
+	} else if len(pod.Spec.Containers) > 0 {
+		info.Default = pod.Spec.Containers[0].Name
+		for _, c := range pod.Spec.Containers {
+			if c.Name == "main" {
+				info.Default = c.Name
+			}
+		}
+	}
+	return info
+}
+
+func containerStatus(cs corev1.ContainerStatus, init bool) types.ContainerStatus {
+	status := types.ContainerStatus{
+		Name:     cs.Name,
+		Init:     init,
+		Restarts: int(cs.RestartCount),
+	}
+	switch {
+	case cs.State.Waiting != nil:
+		status.State = "Waiting"
+		status.Reason = cs.State.Waiting.Reason
+		status.Message = cs.State.Waiting.Message
+	case cs.State.Terminated != nil:
+		status.State = "Terminated"
+		status.Reason = cs.State.Terminated.Reason
+		status.Message = cs.State.Terminated.Message
+		status.ExitCode = cs.State.Terminated.ExitCode
+	case cs.State.Running != nil:
+		status.State = "Running"
+	}
+	if last := cs.LastTerminationState.Terminated; last != nil {
+		status.LastReason = last.Reason
+		status.LastExitCode = last.ExitCode
+	}
+	return status
+}
+
+// podIssue returns the most important failure reason of a pod and its priority
+func podIssue(info types.PodInfo) (string, int) {
+	best, bestPriority := "", 0
+	consider := func(text, reason string, failed bool) {
+		if reason == "" || benignReasons[reason] {
+			if !failed {
+				return
+			}
+			reason = "Error"
+		}
+		priority, ok := reasonPriority[reason]
+		if !ok {
+			priority = 1
+		}
+		if priority > bestPriority {
+			best, bestPriority = text, priority
+		}
+	}
+
+	consider(info.Reason, info.Reason, false)
+	for _, cs := range info.Statuses {
+		switch cs.State {
+		case "Waiting":
+			text := fmt.Sprintf("%s: %s", cs.Name, cs.Reason)
+			if cs.LastReason != "" {
+				text += fmt.Sprintf(" (last: %s, exit %d)", cs.LastReason, cs.LastExitCode)
+				consider(text, cs.LastReason, false)
+			}
+			consider(text, cs.Reason, false)
+		case "Terminated":
+			if cs.ExitCode == 0 && (cs.Reason == "" || cs.Reason == "Completed") {
+				continue
+			}
+			reason := cs.Reason
+			if reason == "" {
+				reason = "Error"
+			}
+			consider(fmt.Sprintf("%s: %s (exit %d)", cs.Name, reason, cs.ExitCode), cs.Reason, cs.ExitCode != 0)
+		}
+	}
+	return best, bestPriority
+}
+
+// jobPodIssues returns the most important pod failure reason per Job UID
+func jobPodIssues(pods []corev1.Pod) map[k8stypes.UID]string {
+	pods = slices.Clone(pods)
+	sort.SliceStable(pods, func(i, j int) bool {
+		return pods[i].CreationTimestamp.After(pods[j].CreationTimestamp.Time)
+	})
+
+	issues := make(map[k8stypes.UID]string)
+	priorities := make(map[k8stypes.UID]int)
+	for _, pod := range pods {
+		owner := metav1.GetControllerOf(&pod)
+		if owner == nil || owner.Kind != "Job" {
+			continue
+		}
+		// Newest pod wins ties
+		if issue, priority := podIssue(podToInfo(pod)); priority > priorities[owner.UID] {
+			issues[owner.UID] = issue
+			priorities[owner.UID] = priority
+		}
+	}
+	return issues
+}
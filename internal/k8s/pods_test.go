@@ -0,0 +1,237 @@
+package k8s
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ginbear/k8s-flowtop/internal/types"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func testPod(name string, labels map[string]string, created time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			Labels:            labels,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "main"}},
+		},
+	}
+}
+
+func podNames(pods []types.PodInfo) []string {
+	names := make([]string, 0, len(pods))
+	for _, p := range pods {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func TestListPods(t *testing.T) {
+	now := time.Now()
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"},
+		Spec: batchv1.JobSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"batch.kubernetes.io/controller-uid": "uid-1"},
+			},
+		},
+	}
+	jobPods := map[string]string{"batch.kubernetes.io/controller-uid": "uid-1"}
+	wfPods := map[string]string{workflowLabel: "build"}
+
+	c := &Client{clientset: fake.NewSimpleClientset(
+		job,
+		testPod("backup-old", jobPods, now.Add(-time.Hour)),
+		testPod("backup-new", jobPods, now),
+		testPod("build-1", wfPods, now),
+		testPod("other", map[string]string{"app": "web"}, now),
+	)}
+
+	tests := []struct {
+		name     string
+		resource types.AsyncResource
+		want     []string
+	}{
+		{
+			name:     "job selector, newest first",
+			resource: types.AsyncResource{Kind: types.KindJob, Name: "backup", Namespace: "default"},
+			want:     []string{"backup-new", "backup-old"},
+		},
+		{
+			name:     "registry pod label",
+			resource: types.AsyncResource{Kind: types.KindWorkflow, Name: "build", Namespace: "default"},
+			want:     []string{"build-1"},
+		},
+		{
+			name:     "kind without pods",
+			resource: types.AsyncResource{Kind: types.KindCronJob, Name: "backup", Namespace: "default"},
+			want:     []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pods, err := c.ListPods(context.Background(), tt.resource)
+			if err != nil {
+				t.Fatalf("ListPods() error = %v", err)
+			}
+			got := podNames(pods)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ListPods() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("ListPods() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestListPodsNodeNames(t *testing.T) {
+	pod := testPod("build-123", map[string]string{workflowLabel: "build"}, time.Now())
+	annotated := testPod("build-456", map[string]string{workflowLabel: "build"}, time.Now())
+	annotated.Annotations = map[string]string{nodeNameAnnotation: "build.test"}
+
+	c := &Client{clientset: fake.NewSimpleClientset(pod, annotated)}
+	r := types.AsyncResource{
+		Kind:      types.KindWorkflow,
+		Name:      "build",
+		Namespace: "default",
+		DAGNodes:  []types.DAGNode{{Name: "compile", PodName: "build-123"}},
+	}
+	pods, err := c.ListPods(context.Background(), r)
+	if err != nil {
+		t.Fatalf("ListPods() error = %v", err)
+	}
+	got := make(map[string]string)
+	for _, p := range pods {
+		got[p.Name] = p.NodeName
+	}
+	if got["build-123"] != "compile" {
+		t.Errorf("node of build-123 = %q, want %q", got["build-123"], "compile")
+	}
+	if got["build-456"] != "test" {
+		t.Errorf("node of build-456 = %q, want %q", got["build-456"], "test")
+	}
+}
+
+func TestStreamLogs(t *testing.T) {
+	cs := fake.NewSimpleClientset()
+	c := &Client{clientset: cs}
+
+	stream, err := c.StreamLogs(context.Background(), "default", "backup-1", LogOptions{
+		Container: "sidecar",
+		Previous:  true,
+		TailLines: 100,
+	})
+	if err != nil {
+		t.Fatalf("StreamLogs() error = %v", err)
+	}
+	defer stream.Close()
+	if data, _ := io.ReadAll(stream); string(data) != "fake logs" {
+		t.Errorf("StreamLogs() read %q", data)
+	}
+
+	var opts *corev1.PodLogOptions
+	for _, a := range cs.Actions() {
+		if a.GetSubresource() == "log" {
+			opts, _ = a.(k8stesting.GenericAction).GetValue().(*corev1.PodLogOptions)
+		}
+	}
+	if opts == nil {
+		t.Fatal("no log request recorded")
+	}
+	if opts.Container != "sidecar" || !opts.Previous || opts.Follow {
+		t.Errorf("log options = %+v", opts)
+	}
+	if opts.TailLines == nil || *opts.TailLines != 100 {
+		t.Errorf("TailLines = %v, want 100", opts.TailLines)
+	}
+}
+
+func TestPodIssue(t *testing.T) {
+	tests := []struct {
+		name         string
+		info         types.PodInfo
+		want         string
+		wantPriority int
+	}{
+		{
+			name: "healthy",
+			info: types.PodInfo{Statuses: []types.ContainerStatus{{Name: "main", State: "Running"}}},
+		},
+		{
+			name: "benign waiting reason",
+			info: types.PodInfo{Statuses: []types.ContainerStatus{{Name: "main", State: "Waiting", Reason: "ContainerCreating"}}},
+		},
+		{
+			name: "completed",
+			info: types.PodInfo{Statuses: []types.ContainerStatus{{Name: "main", State: "Terminated", Reason: "Completed"}}},
+		},
+		{
+			name:         "pod reason",
+			info:         types.PodInfo{Reason: "Evicted"},
+			want:         "Evicted",
+			wantPriority: reasonPriority["Evicted"],
+		},
+		{
+			name: "OOMKilled beats crash loop",
+			info: types.PodInfo{Statuses: []types.ContainerStatus{
+				{Name: "sidecar", State: "Waiting", Reason: "CrashLoopBackOff"},
+				{Name: "main", State: "Terminated", Reason: "OOMKilled", ExitCode: 137},
+			}},
+			want:         "main: OOMKilled (exit 137)",
+			wantPriority: reasonPriority["OOMKilled"],
+		},
+		{
+			name: "crash loop ranked by last termination",
+			info: types.PodInfo{Statuses: []types.ContainerStatus{
+				{Name: "main", State: "Waiting", Reason: "CrashLoopBackOff", LastReason: "OOMKilled", LastExitCode: 137},
+			}},
+			want:         "main: CrashLoopBackOff (last: OOMKilled, exit 137)",
+			wantPriority: reasonPriority["OOMKilled"],
+		},
+		{
+			name: "non-zero exit without reason",
+			info: types.PodInfo{Statuses: []types.ContainerStatus{
+				{Name: "main", State: "Terminated", ExitCode: 1},
+			}},
+			want:         "main: Error (exit 1)",
+			wantPriority: reasonPriority["Error"],
+		},
+		{
+			name: "completed with non-zero exit",
+			info: types.PodInfo{Statuses: []types.ContainerStatus{
+				{Name: "main", State: "Terminated", Reason: "Completed", ExitCode: 2},
+			}},
+			want:         "main: Completed (exit 2)",
+			wantPriority: reasonPriority["Error"],
+		},
+		{
+			name: "unknown reason",
+			info: types.PodInfo{Statuses: []types.ContainerStatus{
+				{Name: "main", State: "Waiting", Reason: "SomethingNew"},
+			}},
+			want:         "main: SomethingNew",
+			wantPriority: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, priority := podIssue(tt.info)
+			if got != tt.want || priority != tt.wantPriority {
+				t.Errorf("podIssue() = %q, %d, want %q, %d", got, priority, tt.want, tt.wantPriority)
+			}
+		})
+	}
+}
@@ -0,0 +1,561 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ginbear/k8s-flowtop/internal/kinds"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Tekton Pipelines and Triggers kinds
+const (
+	kindPipelineRun     types.ResourceKind = "PipelineRun"
+	kindTaskRun         types.ResourceKind = "TaskRun"
+	kindPipeline        types.ResourceKind = "Pipeline"
+	kindEventListener   types.ResourceKind = "EventListener"
+	kindTriggerTemplate types.ResourceKind = "TriggerTemplate"
+)
+
+// Labels Tekton sets on TaskRuns and their pods
+const (
+	pipelineRunLabel  = "tekton.dev/pipelineRun"
+	pipelineTaskLabel = "tekton.dev/pipelineTask"
+	taskRunLabel      = "tekton.dev/taskRun"
+)
+
+var (
+	pipelineRunGVR = schema.GroupVersionResource{
+		Group:    "tekton.dev",
+		Version:  "v1",
+		Resource: "pipelineruns",
+	}
+	taskRunGVR = schema.GroupVersionResource{
+		Group:    "tekton.dev",
+		Version:  "v1",
+		Resource: "taskruns",
+	}
+	pipelineGVR = schema.GroupVersionResource{
+		Group:    "tekton.dev",
+		Version:  "v1",
+		Resource: "pipelines",
+	}
+	eventListenerGVR = schema.GroupVersionResource{
+		Group:    "triggers.tekton.dev",
+		Version:  "v1beta1",
+		Resource: "eventlisteners",
+	}
+	triggerTemplateGVR = schema.GroupVersionResource{
+		Group:    "triggers.tekton.dev",
+		Version:  "v1beta1",
+		Resource: "triggertemplates",
+	}
+)
+
+func init() {
+	kinds.Register(
+		// TaskRuns come first so that their pods, which carry the
+		// PipelineRun label too, belong to them
+		kinds.Kind{
+			Kind:     kindTaskRun,
+			GVR:      taskRunGVR,
+			View:     types.ViewWorkflows,
+			Convert:  convertUnstructured(taskRunToResource),
+			PodLabel: taskRunLabel,
+			Details: attributeDetails(
+				attribute{"task", "Task"},
+				attribute{"pipelineTask", "Pipeline Task"},
+				attribute{"pod", "Pod"},
+			),
+		},
+		kinds.Kind{
+			Kind:     kindPipelineRun,
+			GVR:      pipelineRunGVR,
+			View:     types.ViewWorkflows,
+			Convert:  convertUnstructured(pipelineRunToResource),
+			PodLabel: pipelineRunLabel,
+			Link:     linkTaskRuns,
+			Details:  attributeDetails(attribute{"pipeline", "Pipeline"}),
+		},
+		kinds.Kind{
+			Kind:    kindPipeline,
+			GVR:     pipelineGVR,
+			View:    types.ViewWorkflows,
+			Convert: convertUnstructured(pipelineToResource),
+			Link:    linkPipelineRuns,
+			Details: attributeDetails(attribute{"tasks", "Tasks"}),
+		},
+		kinds.Kind{
+			Kind:    kindEventListener,
+			GVR:     eventListenerGVR,
+			View:    types.ViewEvents,
+			Convert: convertUnstructured(eventListenerToResource),
+			Columns: []kinds.Column{
+				{Header: "TRIGGER", Width: 35, Value: func(r types.AsyncResource) string { return firstWithCount(r.TriggerNames) }},
+			},
+			Details: func(r types.AsyncResource) []kinds.Section {
+				sections := eventDetails(r)
+				sections = append(sections, attributeDetails(
+					attribute{"url", "URL"},
+					attribute{"templates", "Templates"},
+				)(r)...)
+				return sections
+			},
+		},
+		kinds.Kind{
+			Kind:    kindTriggerTemplate,
+			GVR:     triggerTemplateGVR,
+			View:    types.ViewEvents,
+			Convert: convertUnstructured(triggerTemplateToResource),
+			Details: attributeDetails(
+				attribute{"params", "Params"},
+				attribute{"resources", "Resources"},
+			),
+		},
+	)
+}
+
+// resultRef matches references to the results of other pipeline tasks,
+// which order tasks like runAfter does
+var resultRef = regexp.MustCompile(`\$\(tasks\.([^.)]+)\.results\.`)
+
+func pipelineRunToResource(obj unstructured.Unstructured) types.AsyncResource {
+	r := types.AsyncResource{
+		UID:        string(obj.GetUID()),
+		Kind:       kindPipelineRun,
+		Name:       obj.GetName(),
+		Namespace:  obj.GetNamespace(),
+		Attributes: make(map[string]string),
+	}
+	r.Status, r.Message = tektonStatus(obj)
+	setRunTimes(obj, &r)
+
+	// v1 moved the service account under taskRunTemplate
+	if sa, ok, _ := unstructured.NestedString(obj.Object, "spec", "taskRunTemplate", "serviceAccountName"); ok {
+		r.ServiceAccount = sa
+	} else if sa, ok, _ := unstructured.NestedString(obj.Object, "spec", "serviceAccountName"); ok {
+		r.ServiceAccount = sa
+	}
+	if name, ok, _ := unstructured.NestedString(obj.Object, "spec", "pipelineRef", "name"); ok {
+		r.Attributes["pipeline"] = name
+	}
+
+	r.DAGNodes = pipelineTaskNodes(obj)
+	return r
+}
+
+// pipelineTaskNodes returns the tasks of a PipelineRun's resolved pipeline
+// as pending DAG nodes; linkTaskRuns fills in their progress
+func pipelineTaskNodes(obj unstructured.Unstructured) []types.DAGNode {
+	spec, _, _ := unstructured.NestedMap(obj.Object, "status", "pipelineSpec")
+	if spec == nil {
+		spec, _, _ = unstructured.NestedMap(obj.Object, "spec", "pipelineSpec")
+	}
+	tasks, _, _ := unstructured.NestedSlice(spec, "tasks")
+	finally, _, _ := unstructured.NestedSlice(spec, "finally")
+
+	var nodes []types.DAGNode
+	var deps [][]string // tasks each node runs after
+	add := func(t interface{}) {
+		task, ok := t.(map[string]interface{})
+		if !ok {
+			return
+		}
+		name, _ := task["name"].(string)
+		if name == "" {
+			return
+		}
+		template, _, _ := unstructured.NestedString(task, "taskRef", "name")
+		nodes = append(nodes, types.DAGNode{
+			ID:           name,
+			Name:         name,
+			Type:         "Task",
+			Phase:        "Pending",
+			TemplateName: template,
+		})
+		deps = append(deps, taskDeps(task))
+	}
+	for _, t := range tasks {
+		add(t)
+	}
+
+	addChild := func(parent, child string) {
+		i := slices.IndexFunc(nodes, func(n types.DAGNode) bool { return n.ID == parent })
+		if i >= 0 && !slices.Contains(nodes[i].Children, child) {
+			nodes[i].Children = append(nodes[i].Children, child)
+		}
+	}
+	for i, after := range deps {
+		for _, parent := range after {
+			addChild(parent, nodes[i].ID)
+		}
+	}
+
+	// Finally tasks run once all other tasks are done
+	var leaves []string
+	for _, n := range nodes {
+		if len(n.Children) == 0 {
+			leaves = append(leaves, n.ID)
+		}
+	}
+	first := len(nodes)
+	for _, t := range finally {
+		add(t)
+	}
+	for _, n := range nodes[first:] {
+		for _, leaf := range leaves {
+			addChild(leaf, n.ID)
+		}
+	}
+
+	// Skipped tasks never get a TaskRun
+	skipped, _, _ := unstructured.NestedSlice(obj.Object, "status", "skippedTasks")
+	for _, s := range skipped {
+		task, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := task["name"].(string)
+		if i := slices.IndexFunc(nodes, func(n types.DAGNode) bool { return n.ID == name }); i >= 0 {
+			nodes[i].Phase = "Omitted"
+			nodes[i].Message, _ = task["reason"].(string)
+		}
+	}
+	return nodes
+}
+
+// taskDeps returns the tasks a pipeline task runs after
+func taskDeps(task map[string]interface{}) []string {
+	after, _, _ := unstructured.NestedStringSlice(task, "runAfter")
+
+	// Results may be referenced anywhere in params and when expressions
+	refs, _ := json.Marshal([]interface{}{task["params"], task["when"]})
+	for _, m := range resultRef.FindAllStringSubmatch(string(refs), -1) {
+		if !slices.Contains(after, m[1]) {
+			after = append(after, m[1])
+		}
+	}
+	return after
+}
+
+func taskRunToResource(obj unstructured.Unstructured) types.AsyncResource {
+	r := types.AsyncResource{
+		UID:        string(obj.GetUID()),
+		Kind:       kindTaskRun,
+		Name:       obj.GetName(),
+		Namespace:  obj.GetNamespace(),
+		Attributes: make(map[string]string),
+	}
+	r.Status, r.Message = tektonStatus(obj)
+	setRunTimes(obj, &r)
+
+	if sa, ok, _ := unstructured.NestedString(obj.Object, "spec", "serviceAccountName"); ok {
+		r.ServiceAccount = sa
+	}
+
+	// Extract parent from ownerReferences (for TaskRuns of a PipelineRun)
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind == string(kindPipelineRun) {
+			r.ParentKind = ref.Kind
+			r.ParentName = ref.Name
+			break
+		}
+	}
+
+	r.Attributes["pipelineTask"] = obj.GetLabels()[pipelineTaskLabel]
+	if name, ok, _ := unstructured.NestedString(obj.Object, "spec", "taskRef", "name"); ok {
+		r.Attributes["task"] = name
+	}
+	podName, _, _ := unstructured.NestedString(obj.Object, "status", "podName")
+	r.Attributes["pod"] = podName
+
+	retries, _, _ := unstructured.NestedSlice(obj.Object, "status", "retriesStatus")
+	r.Retries = len(retries)
+	if retries, ok, _ := unstructured.NestedInt64(obj.Object, "spec", "retries"); ok {
+		r.MaxRetries = int(retries)
+	}
+
+	r.DAGNodes = stepNodes(obj)
+	return r
+}
+
+// stepNodes returns the steps of a TaskRun as a chain of DAG nodes
+func stepNodes(obj unstructured.Unstructured) []types.DAGNode {
+	steps, _, _ := unstructured.NestedSlice(obj.Object, "status", "steps")
+
+	var nodes []types.DAGNode
+	for _, s := range steps {
+		step, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := step["name"].(string)
+		n := types.DAGNode{ID: name, Name: name, Type: "Step", Phase: "Pending"}
+
+		switch {
+		case step["terminated"] != nil:
+			n.StartedAt = nestedTime(step, "terminated", "startedAt")
+			n.FinishedAt = nestedTime(step, "terminated", "finishedAt")
+			exitCode, _, _ := unstructured.NestedInt64(step, "terminated", "exitCode")
+			n.ExitCode = fmt.Sprint(exitCode)
+			n.Phase = "Succeeded"
+			if exitCode != 0 {
+				n.Phase = "Failed"
+				n.Message, _, _ = unstructured.NestedString(step, "terminated", "reason")
+			}
+		case step["running"] != nil:
+			n.StartedAt = nestedTime(step, "running", "startedAt")
+			n.Phase = "Running"
+		}
+		if n.StartedAt != nil && n.FinishedAt != nil {
+			n.Duration = n.FinishedAt.Sub(*n.StartedAt)
+		}
+
+		if len(nodes) > 0 {
+			prev := &nodes[len(nodes)-1]
+			prev.Children = append(prev.Children, n.ID)
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// linkTaskRuns shows the progress of TaskRuns on the task nodes of their
+// PipelineRun
+func linkTaskRuns(resources []types.AsyncResource) {
+	runs := make(map[string]*types.AsyncResource)
+	for i, r := range resources {
+		if r.Kind == kindPipelineRun {
+			runs[r.Namespace+"/"+r.Name] = &resources[i]
+		}
+	}
+
+	for _, tr := range resources {
+		if tr.Kind != kindTaskRun || tr.ParentName == "" {
+			continue
+		}
+		pr, ok := runs[tr.Namespace+"/"+tr.ParentName]
+		if !ok {
+			continue
+		}
+		name := tr.Attributes["pipelineTask"]
+		if name == "" {
+			name = tr.Name
+		}
+
+		n := types.DAGNode{
+			ID:           name,
+			Name:         name,
+			Type:         "Task",
+			Phase:        string(tr.Status),
+			TemplateName: tr.Attributes["task"],
+			StartedAt:    tr.StartTime,
+			FinishedAt:   tr.EndTime,
+			Duration:     tr.Duration,
+			Message:      tr.Message,
+			PodName:      tr.Attributes["pod"],
+		}
+		if tr.Retries > 0 {
+			n.Attempts = tr.Retries + 1
+		}
+
+		i := slices.IndexFunc(pr.DAGNodes, func(d types.DAGNode) bool { return d.ID == name })
+		if i < 0 {
+			pr.DAGNodes = append(pr.DAGNodes, n)
+			continue
+		}
+		n.Children = pr.DAGNodes[i].Children
+		if n.TemplateName == "" {
+			n.TemplateName = pr.DAGNodes[i].TemplateName
+		}
+		pr.DAGNodes[i] = n
+	}
+}
+
+func pipelineToResource(obj unstructured.Unstructured) types.AsyncResource {
+	r := types.AsyncResource{
+		UID:        string(obj.GetUID()),
+		Kind:       kindPipeline,
+		Name:       obj.GetName(),
+		Namespace:  obj.GetNamespace(),
+		Status:     types.StatusUnknown,
+		Attributes: make(map[string]string),
+	}
+
+	var names []string
+	for _, field := range []string{"tasks", "finally"} {
+		tasks, _, _ := unstructured.NestedSlice(obj.Object, "spec", field)
+		for _, t := range tasks {
+			if task, ok := t.(map[string]interface{}); ok {
+				if name, ok := task["name"].(string); ok {
+					names = append(names, name)
+				}
+			}
+		}
+	}
+	r.Attributes["tasks"] = strings.Join(names, ", ")
+	return r
+}
+
+// linkPipelineRuns shows the status of the latest run on each Pipeline
+func linkPipelineRuns(resources []types.AsyncResource) {
+	latest := make(map[string]types.AsyncResource)
+	for _, r := range resources {
+		if r.Kind != kindPipelineRun || r.Attributes["pipeline"] == "" {
+			continue
+		}
+		key := r.Namespace + "/" + r.Attributes["pipeline"]
+		// Runs that have not started yet are the newest
+		prev, ok := latest[key]
+		if !ok || r.StartTime == nil || (prev.StartTime != nil && r.StartTime.After(*prev.StartTime)) {
+			latest[key] = r
+		}
+	}
+
+	for i, r := range resources {
+		if r.Kind != kindPipeline {
+			continue
+		}
+		if run, ok := latest[r.Namespace+"/"+r.Name]; ok {
+			resources[i].Status = run.Status
+			resources[i].LastRun = run.StartTime
+		}
+	}
+}
+
+func eventListenerToResource(obj unstructured.Unstructured) types.AsyncResource {
+	r := types.AsyncResource{
+		UID:        string(obj.GetUID()),
+		Kind:       kindEventListener,
+		Name:       obj.GetName(),
+		Namespace:  obj.GetNamespace(),
+		Status:     types.StatusUnknown,
+		Attributes: make(map[string]string),
+	}
+
+	if sa, ok, _ := unstructured.NestedString(obj.Object, "spec", "serviceAccountName"); ok {
+		r.ServiceAccount = sa
+	}
+
+	var templates []string
+	triggers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "triggers")
+	for _, t := range triggers {
+		trigger, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := trigger["name"].(string)
+		if name == "" {
+			name, _ = trigger["triggerRef"].(string)
+		}
+		if name != "" {
+			r.TriggerNames = append(r.TriggerNames, name)
+		}
+		if ref, ok, _ := unstructured.NestedString(trigger, "template", "ref"); ok {
+			templates = append(templates, ref)
+		}
+	}
+	r.Attributes["templates"] = strings.Join(templates, ", ")
+	r.Attributes["url"], _, _ = unstructured.NestedString(obj.Object, "status", "address", "url")
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		if cond, ok := c.(map[string]interface{}); ok && cond["type"] == "Ready" {
+			if cond["status"] == "True" {
+				r.Status = types.StatusRunning
+			} else {
+				r.Status = types.StatusFailed
+				r.Message, _ = cond["message"].(string)
+			}
+		}
+	}
+	return r
+}
+
+func triggerTemplateToResource(obj unstructured.Unstructured) types.AsyncResource {
+	r := types.AsyncResource{
+		UID:        string(obj.GetUID()),
+		Kind:       kindTriggerTemplate,
+		Name:       obj.GetName(),
+		Namespace:  obj.GetNamespace(),
+		Status:     types.StatusUnknown,
+		Attributes: make(map[string]string),
+	}
+
+	var params []string
+	specParams, _, _ := unstructured.NestedSlice(obj.Object, "spec", "params")
+	for _, p := range specParams {
+		if param, ok := p.(map[string]interface{}); ok {
+			if name, ok := param["name"].(string); ok {
+				params = append(params, name)
+			}
+		}
+	}
+	r.Attributes["params"] = strings.Join(params, ", ")
+
+	// Resources the template creates, e.g. "PipelineRun/build-"
+	var created []string
+	templates, _, _ := unstructured.NestedSlice(obj.Object, "spec", "resourcetemplates")
+	for _, t := range templates {
+		tmpl, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		kind, _ := tmpl["kind"].(string)
+		name, _, _ := unstructured.NestedString(tmpl, "metadata", "name")
+		if name == "" {
+			name, _, _ = unstructured.NestedString(tmpl, "metadata", "generateName")
+		}
+		created = append(created, kind+"/"+name)
+	}
+	r.Attributes["resources"] = strings.Join(created, ", ")
+	return r
+}
+
+// tektonStatus reads the Succeeded condition of a PipelineRun or TaskRun
+func tektonStatus(obj unstructured.Unstructured) (types.ResourceStatus, string) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["type"] != "Succeeded" {
+			continue
+		}
+		reason, _ := cond["reason"].(string)
+		message, _ := cond["message"].(string)
+		switch cond["status"] {
+		case "True":
+			return types.StatusSucceeded, ""
+		case "False":
+			if message != "" {
+				return types.StatusFailed, reason + ": " + message
+			}
+			return types.StatusFailed, reason
+		default:
+			// e.g. PipelineRunPending, or a TaskRun waiting for its pod
+			if strings.HasSuffix(reason, "Pending") {
+				return types.StatusPending, message
+			}
+			return types.StatusRunning, ""
+		}
+	}
+	return types.StatusPending, ""
+}
+
+// setRunTimes reads the start and completion time of a Tekton run
+func setRunTimes(obj unstructured.Unstructured, r *types.AsyncResource) {
+	r.StartTime = nestedTime(obj.Object, "status", "startTime")
+	r.EndTime = nestedTime(obj.Object, "status", "completionTime")
+	if r.StartTime != nil {
+		if r.EndTime != nil {
+			r.Duration = r.EndTime.Sub(*r.StartTime)
+		} else {
+			r.Duration = time.Since(*r.StartTime)
+		}
+	}
+}
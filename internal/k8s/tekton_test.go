@@ -0,0 +1,70 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/ginbear/k8s-flowtop/internal/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func withConditions(conditions ...interface{}) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{"conditions": conditions},
+	}}
+}
+
+func TestTektonStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		obj         unstructured.Unstructured
+		wantStatus  types.ResourceStatus
+		wantMessage string
+	}{
+		{
+			name:       "no status",
+			obj:        unstructured.Unstructured{Object: map[string]interface{}{}},
+			wantStatus: types.StatusPending,
+		},
+		{
+			name:       "succeeded",
+			obj:        withConditions(map[string]interface{}{"type": "Succeeded", "status": "True", "reason": "Succeeded"}),
+			wantStatus: types.StatusSucceeded,
+		},
+		{
+			name:        "failed with message",
+			obj:         withConditions(map[string]interface{}{"type": "Succeeded", "status": "False", "reason": "Failed", "message": "task build failed"}),
+			wantStatus:  types.StatusFailed,
+			wantMessage: "Failed: task build failed",
+		},
+		{
+			name:        "failed without message",
+			obj:         withConditions(map[string]interface{}{"type": "Succeeded", "status": "False", "reason": "PipelineRunTimeout"}),
+			wantStatus:  types.StatusFailed,
+			wantMessage: "PipelineRunTimeout",
+		},
+		{
+			name:        "pending",
+			obj:         withConditions(map[string]interface{}{"type": "Succeeded", "status": "Unknown", "reason": "PipelineRunPending", "message": "waiting"}),
+			wantStatus:  types.StatusPending,
+			wantMessage: "waiting",
+		},
+		{
+			name:       "running",
+			obj:        withConditions(map[string]interface{}{"type": "Succeeded", "status": "Unknown", "reason": "Running"}),
+			wantStatus: types.StatusRunning,
+		},
+		{
+			name:       "other conditions are ignored",
+			obj:        withConditions(map[string]interface{}{"type": "Ready", "status": "False"}, "invalid"),
+			wantStatus: types.StatusPending,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, message := tektonStatus(tt.obj)
+			if status != tt.wantStatus || message != tt.wantMessage {
+				t.Errorf("tektonStatus() = %s, %q, want %s, %q", status, message, tt.wantStatus, tt.wantMessage)
+			}
+		})
+	}
+}
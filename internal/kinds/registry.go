@@ -0,0 +1,144 @@
+// Package kinds is the registry of resource kinds flowtop lists. Each kind
+// supplies how to read it from the cluster and how to show it, so the
+// listing, tabs, table and detail view need no per-kind code.
+package kinds
+
+import (
+	"github.com/ginbear/k8s-flowtop/internal/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Kind describes a resource kind
+type Kind struct {
+	Kind types.ResourceKind
+
+	// GVR is the resource the kind is read from; discovery may pick
+	// another served version
+	GVR schema.GroupVersionResource
+
+	// View is the tab listing the kind besides All; ViewAll lists it in All
+	// only, ViewQueues in the queue overview only
+	View types.ViewMode
+
+	// ClusterScoped kinds are listed whatever the namespace
+	ClusterScoped bool
+
+	// Hidden kinds are only read for Link and not listed as rows
+	Hidden bool
+
+	// Convert turns a listed object into a row
+	Convert func(obj unstructured.Unstructured) (types.AsyncResource, error)
+
+	// Columns are shown in the kind's tab, when it has no schedule columns
+	Columns []Column
+
+	// Details returns extra sections of the detail overview
+	Details func(r types.AsyncResource) []Section
+
+	// PodLabel is the label naming the object on the pods it runs, if any
+	PodLabel string
+
+	// Link relates the listed rows of all kinds, e.g. to show the progress
+	// of children on their parent
+	Link func(resources []types.AsyncResource)
+}
+
+// Column is a table column a kind contributes to its tab
+type Column struct {
+	Header string
+	Width  int
+	Value  func(r types.AsyncResource) string // "" is shown as "-"
+}
+
+// Section is a titled group of fields in the detail overview; an empty
+// title adds the fields to the basic info
+type Section struct {
+	Title  string
+	Fields []Field
+}
+
+// Field is a labelled value in the detail overview
+type Field struct {
+	Label string
+	Value string
+}
+
+var registry []Kind
+
+// Register adds kinds; kinds are listed in registration order and a later
+// registration of the same kind replaces the earlier one
+func Register(ks ...Kind) {
+	for _, k := range ks {
+		replaced := false
+		for i := range registry {
+			if registry[i].Kind == k.Kind {
+				registry[i] = k
+				replaced = true
+			}
+		}
+		if !replaced {
+			registry = append(registry, k)
+		}
+	}
+}
+
+// All returns the registered kinds
+func All() []Kind {
+	return append([]Kind(nil), registry...)
+}
+
+// Lookup returns a registered kind
+func Lookup(kind types.ResourceKind) (Kind, bool) {
+	for _, k := range registry {
+		if k.Kind == kind {
+			return k, true
+		}
+	}
+	return Kind{}, false
+}
+
+// InView returns the kinds listed in a tab; Hidden kinds are in none
+func InView(v types.ViewMode) []Kind {
+	var ks []Kind
+	for _, k := range registry {
+		if k.Hidden {
+			continue
+		}
+		if k.View == v || (v == types.ViewAll && k.View != types.ViewQueues) {
+			ks = append(ks, k)
+		}
+	}
+	return ks
+}
+
+// ViewColumns returns the columns the kinds of a tab contribute, merged
+// by header in registration order
+func ViewColumns(v types.ViewMode) []Column {
+	var cols []Column
+	seen := make(map[string]bool)
+	for _, k := range InView(v) {
+		for _, c := range k.Columns {
+			if !seen[c.Header] {
+				seen[c.Header] = true
+				cols = append(cols, c)
+			}
+		}
+	}
+	return cols
+}
+
+// ColumnValue returns a row's value for a column header, using the
+// column of the row's own kind
+func ColumnValue(r types.AsyncResource, header string) string {
+	k, ok := Lookup(r.Kind)
+	if !ok {
+		return ""
+	}
+	for _, c := range k.Columns {
+		if c.Header == header {
+			return c.Value(r)
+		}
+	}
+	return ""
+}
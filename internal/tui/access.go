@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ginbear/k8s-flowtop/internal/k8s"
+	"github.com/ginbear/k8s-flowtop/internal/kinds"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+)
+
+var (
+	forbiddenStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("203"))
+	deniedHintStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("238")).Strikethrough(true)
+)
+
+// accessMsg carries the results of permission checks
+type accessMsg struct {
+	client *k8s.Client // client the checks ran through
+	access types.Access
+}
+
+// checkReadAccess checks which kinds may be listed and watched
+func (m Model) checkReadAccess() tea.Cmd {
+	client := m.k8sClient
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), actionTimeout)
+		defer cancel()
+
+		return accessMsg{client: client, access: client.CheckReadAccess(ctx)}
+	}
+}
+
+// checkWriteAccess checks the write permissions in the namespaces of the
+// listed resources that have not been checked yet
+func (m *Model) checkWriteAccess() tea.Cmd {
+	var namespaces []string
+	if ns := m.k8sClient.GetNamespace(); ns != "" {
+		namespaces = append(namespaces, ns)
+	} else {
+		for _, r := range m.resources {
+			namespaces = append(namespaces, r.Namespace)
+		}
+	}
+
+	var unchecked []string
+	for _, ns := range namespaces {
+		if !m.accessChecked[ns] {
+			m.accessChecked[ns] = true
+			unchecked = append(unchecked, ns)
+		}
+	}
+	if len(unchecked) == 0 {
+		return nil
+	}
+
+	client := m.k8sClient
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), actionTimeout)
+		defer cancel()
+
+		return accessMsg{client: client, access: client.CheckWriteAccess(ctx, unchecked)}
+	}
+}
+
+// mergeAccess records permission check results
+func (m *Model) mergeAccess(msg accessMsg) {
+	if msg.client != m.k8sClient {
+		return
+	}
+	for k, v := range msg.access {
+		m.access[k] = v
+	}
+}
+
+// viewKinds returns the kinds listed in a view
+func viewKinds(v types.ViewMode) []types.ResourceKind {
+	if v == types.ViewKubeEvents {
+		return []types.ResourceKind{types.KindEvent}
+	}
+	var ks []types.ResourceKind
+	for _, k := range kinds.InView(v) {
+		ks = append(ks, k.Kind)
+	}
+	return ks
+}
+
+// forbiddenKinds returns the kinds of a view the user may not list
+// (or watch, for the event stream)
+func (m Model) forbiddenKinds(v types.ViewMode) []types.ResourceKind {
+	ns := m.k8sClient.GetNamespace()
+	var forbidden []types.ResourceKind
+	for _, kind := range viewKinds(v) {
+		denied := m.forbidden[kind] || !m.access.Allowed(ns, kind, "list")
+		if kind == types.KindEvent {
+			denied = denied || !m.access.Allowed(ns, kind, "watch")
+		}
+		if denied {
+			forbidden = append(forbidden, kind)
+		}
+	}
+	return forbidden
+}
+
+// renderForbidden renders the kinds of the current view that are not
+// installed, cannot be listed, or failed to list
+func (m Model) renderForbidden() string {
+	var lines []string
+	if absent := m.absentKinds(m.viewMode); len(absent) > 0 {
+		lines = append(lines, detailHintStyle.Render("∅ not installed: "+kindNames(absent)))
+	}
+	if forbidden := m.forbiddenKinds(m.viewMode); len(forbidden) > 0 {
+		lines = append(lines, forbiddenStyle.Render("⊘ forbidden: "+kindNames(forbidden)+" (no permission to list)"))
+	}
+	var failures []string
+	for _, kind := range viewKinds(m.viewMode) {
+		if err := m.listErrors[kind]; err != nil {
+			failures = append(failures, fmt.Sprintf("%s (%v)", kind, err))
+		}
+	}
+	if len(failures) > 0 {
+		lines = append(lines, statusErrStyle.Render("✗ failed to list: "+strings.Join(failures, ", ")))
+	}
+	return strings.Join(lines, "  ")
+}
+
+// kindNames joins kind names for display
+func kindNames(kinds []types.ResourceKind) string {
+	names := make([]string, len(kinds))
+	for i, kind := range kinds {
+		names[i] = string(kind)
+	}
+	return strings.Join(names, ", ")
+}
+
+// refuseDenied reports whether a key is an action on the selected resource
+// that RBAC does not permit, showing the reason in the status line
+func (m *Model) refuseDenied(msg tea.KeyMsg) bool {
+	r := m.selectedResource()
+	if r == nil {
+		return false
+	}
+	for _, h := range actionHints(*r) {
+		if msg.String() != h.key {
+			continue
+		}
+		if denied := h.denied(m.access, r.Namespace); denied != "" {
+			m.setStatus("", fmt.Errorf("%s not permitted: %s", h.label, denied))
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,631 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ginbear/k8s-flowtop/internal/audit"
+	"github.com/ginbear/k8s-flowtop/internal/k8s"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+)
+
+// Action timing
+const (
+	actionTimeout = 15 * time.Second
+	statusTTL     = 10 * time.Second
+)
+
+var (
+	statusOKStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("34"))
+	statusErrStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+)
+
+// actionResultMsg reports the outcome of an action
+type actionResultMsg struct {
+	text    string
+	err     error
+	created *types.AsyncResource // resource created by the action, shown right away
+	audit   []audit.Entry        // audit log records of the action
+}
+
+// deletePreviewMsg carries the pods that would be deleted along with a resource
+type deletePreviewMsg struct {
+	resource types.AsyncResource
+	pods     []types.PodInfo
+	err      error
+}
+
+// retryPreviewMsg carries the pods a Workflow retry would delete
+type retryPreviewMsg struct {
+	resource types.AsyncResource
+	pods     []string
+	err      error
+}
+
+// paramsMsg carries the parameters of a CronWorkflow about to be submitted
+type paramsMsg struct {
+	resource types.AsyncResource
+	params   []types.NodeIO
+	err      error
+}
+
+// writeBindings returns the keys of the actions that modify the cluster
+func (k KeyMap) writeBindings() []key.Binding {
+	return []key.Binding{k.Suspend, k.RunNow, k.Retry, k.Resubmit, k.Stop, k.Terminate, k.Delete, k.Cleanup}
+}
+
+// readOnlyReason returns why write actions are disabled, or "" if allowed
+func (m Model) readOnlyReason() string {
+	return m.cfg.ReadOnlyReason(m.k8sClient.GetContext())
+}
+
+// refuseWrite reports whether a key is a write action refused in
+// read-only mode, showing the reason in the status line
+func (m *Model) refuseWrite(msg tea.KeyMsg) bool {
+	reason := m.readOnlyReason()
+	if reason == "" {
+		return false
+	}
+	for _, b := range m.keys.writeBindings() {
+		if key.Matches(msg, b) {
+			m.setStatus("", fmt.Errorf("%s refused: read-only mode (%s)", b.Help().Desc, reason))
+			return true
+		}
+	}
+	return false
+}
+
+// runAction returns a command running fn on target and reporting success or failure
+func (m Model) runAction(verb string, target types.AsyncResource, success string, fn func(ctx context.Context) error) tea.Cmd {
+	client := m.k8sClient
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), actionTimeout)
+		defer cancel()
+
+		err := fn(ctx)
+		return actionResultMsg{text: success, err: err, audit: []audit.Entry{newAuditEntry(client, verb, target, err)}}
+	}
+}
+
+// runCreate returns a command running an action on target that creates a
+// resource, reported as "<success> namespace/name"
+func (m Model) runCreate(verb string, target types.AsyncResource, success string, fn func(ctx context.Context) (types.AsyncResource, error)) tea.Cmd {
+	client := m.k8sClient
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), actionTimeout)
+		defer cancel()
+
+		created, err := fn(ctx)
+		entry := newAuditEntry(client, verb, target, err)
+		if err != nil {
+			return actionResultMsg{err: err, audit: []audit.Entry{entry}}
+		}
+		entry.Created = created.Name
+		return actionResultMsg{
+			text:    fmt.Sprintf("%s %s/%s", success, created.Namespace, created.Name),
+			created: &created,
+			audit:   []audit.Entry{entry},
+		}
+	}
+}
+
+// newAuditEntry records the outcome of an action on a resource
+func newAuditEntry(client *k8s.Client, verb string, r types.AsyncResource, err error) audit.Entry {
+	e := audit.Entry{
+		Time:      time.Now(),
+		Context:   client.GetContext(),
+		User:      client.GetUser(),
+		Verb:      verb,
+		Kind:      string(r.Kind),
+		Namespace: r.Namespace,
+		Name:      r.Name,
+		Result:    audit.ResultSuccess,
+	}
+	if err != nil {
+		e.Result = audit.ResultError
+		e.Error = err.Error()
+	}
+	return e
+}
+
+// setStatus shows an action result in the status line
+func (m *Model) setStatus(text string, err error) {
+	m.status = text
+	m.statusErr = err
+	m.statusAt = time.Now()
+}
+
+// selectedResource returns the resource under the cursor, if any
+func (m Model) selectedResource() *types.AsyncResource {
+	if m.viewMode == types.ViewKubeEvents {
+		return nil
+	}
+	if m.cursor >= 0 && m.cursor < len(m.filteredCache) {
+		return &m.filteredCache[m.cursor]
+	}
+	return nil
+}
+
+// toggleSuspend asks to suspend or resume the selected schedule
+func (m *Model) toggleSuspend() {
+	r := m.selectedResource()
+	if r == nil {
+		return
+	}
+	if r.Kind != types.KindCronJob && r.Kind != types.KindCronWorkflow {
+		m.setStatus("", fmt.Errorf("suspend is only available for CronJob and CronWorkflow"))
+		return
+	}
+
+	target := *r
+	suspend := !target.Suspended
+	verb, done := "Suspend", "Suspended"
+	if !suspend {
+		verb, done = "Resume", "Resumed"
+	}
+	client := m.k8sClient
+	action := m.runAction(strings.ToLower(verb), target, fmt.Sprintf("%s %s %s/%s", done, target.Kind, target.Namespace, target.Name),
+		func(ctx context.Context) error {
+			return client.SetSuspend(ctx, target, suspend)
+		})
+	m.confirm(fmt.Sprintf("%s %s?", verb, target.Kind), target, action)
+}
+
+// triggerRun asks to start a run of the selected schedule right away.
+// CronWorkflow parameters are fetched first so they can be overridden.
+func (m *Model) triggerRun() tea.Cmd {
+	r := m.selectedResource()
+	if r == nil {
+		return nil
+	}
+	target := *r
+	client := m.k8sClient
+
+	switch target.Kind {
+	case types.KindCronJob:
+		action := m.runCreate("trigger", target, "Created Job", func(ctx context.Context) (types.AsyncResource, error) {
+			return client.TriggerCronJob(ctx, target)
+		})
+		m.confirm("Run CronJob now?", target, action, renderField("Creates", "Job from spec.jobTemplate"))
+		return nil
+	case types.KindCronWorkflow:
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), actionTimeout)
+			defer cancel()
+
+			params, err := client.CronWorkflowParameters(ctx, target)
+			return paramsMsg{resource: target, params: params, err: err}
+		}
+	default:
+		m.setStatus("", fmt.Errorf("run now is only available for CronJob and CronWorkflow"))
+		return nil
+	}
+}
+
+// confirmSubmit opens the run-now dialog for a CronWorkflow with its parameters
+func (m *Model) confirmSubmit(msg paramsMsg) tea.Cmd {
+	if msg.err != nil {
+		m.setStatus("", msg.err)
+		return nil
+	}
+	target := msg.resource
+	client := m.k8sClient
+	runCreate := m.runCreate
+	submit := func(params map[string]string) tea.Cmd {
+		return runCreate("trigger", target, "Submitted Workflow", func(ctx context.Context) (types.AsyncResource, error) {
+			return client.SubmitCronWorkflow(ctx, target, params)
+		})
+	}
+	return m.confirmWithFields("Run CronWorkflow now?", target, msg.params, submit,
+		renderField("Creates", "Workflow from spec.workflowSpec"))
+}
+
+// Workflow actions, matching the argo CLI commands
+type workflowAction int
+
+const (
+	actionRetry workflowAction = iota
+	actionResubmit
+	actionStop
+	actionTerminate
+)
+
+// workflowAction asks to run an argo-style action on the selected Workflow.
+// A retry first fetches the pods it would delete so the dialog can preview them.
+func (m *Model) workflowAction(a workflowAction) tea.Cmd {
+	r := m.selectedResource()
+	if r == nil {
+		return nil
+	}
+	if r.Kind != types.KindWorkflow {
+		m.setStatus("", fmt.Errorf("this action is only available for Workflow"))
+		return nil
+	}
+	target := *r
+	client := m.k8sClient
+	finished := target.Status == types.StatusSucceeded || target.Status == types.StatusFailed
+
+	switch a {
+	case actionRetry:
+		if target.Status != types.StatusFailed {
+			m.setStatus("", fmt.Errorf("only failed workflows can be retried"))
+			return nil
+		}
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), actionTimeout)
+			defer cancel()
+
+			pods, err := client.RetryPods(ctx, target)
+			return retryPreviewMsg{resource: target, pods: pods, err: err}
+		}
+	case actionResubmit:
+		action := m.runCreate("resubmit", target, "Resubmitted as Workflow", func(ctx context.Context) (types.AsyncResource, error) {
+			return client.ResubmitWorkflow(ctx, target)
+		})
+		m.confirm("Resubmit Workflow?", target, action, renderField("Effect", "submit a new copy of this Workflow"))
+	case actionStop, actionTerminate:
+		if finished {
+			m.setStatus("", fmt.Errorf("workflow %s has already finished", target.Name))
+			return nil
+		}
+		strategy, effect := "Stop", "stop after running steps; exit handlers run"
+		if a == actionTerminate {
+			strategy, effect = "Terminate", "kill immediately; exit handlers are skipped"
+		}
+		action := m.runAction(strings.ToLower(strategy), target, fmt.Sprintf("Requested %s of Workflow %s/%s", strings.ToLower(strategy), target.Namespace, target.Name),
+			func(ctx context.Context) error {
+				return client.ShutdownWorkflow(ctx, target, strategy)
+			})
+
+		// Steps still running or waiting to run are the ones cut short
+		affected := []string{fmt.Sprintf("%s %s/%s", target.Kind, target.Namespace, target.Name)}
+		for _, n := range target.DAGNodes {
+			if n.PodName != "" && (n.Phase == "Running" || n.Phase == "Pending") {
+				affected = append(affected, fmt.Sprintf("Pod %s/%s  step %s, %s", target.Namespace, n.PodName, n.Name, strings.ToLower(n.Phase)))
+			}
+		}
+		m.confirmAffected(strategy+" Workflow?", target, action, affected, renderField("Effect", effect))
+	}
+	return nil
+}
+
+// confirmRetry opens the retry dialog, previewing the pods it deletes
+func (m *Model) confirmRetry(msg retryPreviewMsg) tea.Cmd {
+	if msg.err != nil {
+		m.setStatus("", msg.err)
+		return nil
+	}
+	target := msg.resource
+	client := m.k8sClient
+	action := m.runAction("retry", target, fmt.Sprintf("Retrying Workflow %s/%s", target.Namespace, target.Name),
+		func(ctx context.Context) error {
+			return client.RetryWorkflow(ctx, target)
+		})
+
+	affected := []string{fmt.Sprintf("%s %s/%s", target.Kind, target.Namespace, target.Name)}
+	for _, pod := range msg.pods {
+		affected = append(affected, fmt.Sprintf("Pod %s/%s", target.Namespace, pod))
+	}
+	m.confirmAffected("Retry Workflow?", target, action, affected, renderField("Effect", "re-run failed steps, delete their pods"))
+	return nil
+}
+
+// Deletion propagation policies, default first
+var propagationPolicies = []string{"Background", "Foreground", "Orphan"}
+
+// defaultCleanupAge is the initial age limit offered by cleanup
+const defaultCleanupAge = "24h"
+
+// deleteResource fetches the pods of the selected Job or Workflow so the
+// delete dialog can preview them
+func (m *Model) deleteResource() tea.Cmd {
+	r := m.selectedResource()
+	if r == nil {
+		return nil
+	}
+	if r.Kind != types.KindJob && r.Kind != types.KindWorkflow {
+		m.setStatus("", fmt.Errorf("delete is only available for Job and Workflow"))
+		return nil
+	}
+	target := *r
+	client := m.k8sClient
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), actionTimeout)
+		defer cancel()
+
+		pods, err := client.ListPods(ctx, target)
+		return deletePreviewMsg{resource: target, pods: pods, err: err}
+	}
+}
+
+// confirmDelete opens the delete dialog with a propagation policy choice
+func (m *Model) confirmDelete(msg deletePreviewMsg) tea.Cmd {
+	if msg.err != nil {
+		m.setStatus("", msg.err)
+		return nil
+	}
+	target := msg.resource
+	client := m.k8sClient
+	runAction := m.runAction
+
+	c := &confirmDialog{
+		title:   fmt.Sprintf("Delete %s?", target.Kind),
+		details: m.targetDetails(target),
+		fields:  []confirmField{newChoiceField("propagation", propagationPolicies...)},
+		preview: func(values map[string]string) []string {
+			affected := []string{fmt.Sprintf("%s %s/%s", target.Kind, target.Namespace, target.Name)}
+			if values["propagation"] == "Orphan" {
+				return affected
+			}
+			for _, pod := range msg.pods {
+				affected = append(affected, fmt.Sprintf("Pod %s/%s", pod.Namespace, pod.Name))
+			}
+			return affected
+		},
+		submit: func(values map[string]string) tea.Cmd {
+			propagation := values["propagation"]
+			return runAction("delete", target, fmt.Sprintf("Deleted %s %s/%s (%s)", target.Kind, target.Namespace, target.Name, propagation),
+				func(ctx context.Context) error {
+					return client.DeleteResource(ctx, target, propagation)
+				})
+		},
+	}
+	m.guardProtected(c, target.Namespace)
+	return m.openConfirm(c)
+}
+
+// rerunJob asks to create a copy of the selected finished Job
+func (m *Model) rerunJob() {
+	r := m.selectedResource()
+	if r == nil {
+		return
+	}
+	if r.Status != types.StatusSucceeded && r.Status != types.StatusFailed {
+		m.setStatus("", fmt.Errorf("only finished jobs can be re-run"))
+		return
+	}
+	target := *r
+	client := m.k8sClient
+	action := m.runCreate("rerun", target, "Created Job", func(ctx context.Context) (types.AsyncResource, error) {
+		return client.RerunJob(ctx, target)
+	})
+	m.confirm("Re-run Job?", target, action, renderField("Creates", "Job with the same spec"))
+}
+
+// cleanup asks to delete the finished Jobs and Workflows of the current
+// view older than an age limit
+func (m *Model) cleanup() tea.Cmd {
+	if m.viewMode == types.ViewKubeEvents {
+		return nil
+	}
+
+	var finished []types.AsyncResource
+	namespaces := make(map[string]bool)
+	for _, r := range m.filterResources() {
+		if (r.Kind == types.KindJob || r.Kind == types.KindWorkflow) &&
+			(r.Status == types.StatusSucceeded || r.Status == types.StatusFailed) && r.EndTime != nil &&
+			m.access.Allowed(r.Namespace, r.Kind, "delete") {
+			finished = append(finished, r)
+			namespaces[r.Namespace] = true
+		}
+	}
+	if len(finished) == 0 {
+		m.setStatus("", fmt.Errorf("no finished jobs or workflows you may delete in this view"))
+		return nil
+	}
+
+	// candidates returns the resources finished before the age limit
+	candidates := func(age string) ([]types.AsyncResource, error) {
+		d, err := parseAge(age)
+		if err != nil {
+			return nil, err
+		}
+		cutoff := time.Now().Add(-d)
+		var old []types.AsyncResource
+		for _, r := range finished {
+			if r.EndTime.Before(cutoff) {
+				old = append(old, r)
+			}
+		}
+		return old, nil
+	}
+
+	scope := "all"
+	if len(namespaces) == 1 {
+		scope = finished[0].Namespace
+	} else if m.cfg.IsProduction(m.k8sClient.GetContext()) {
+		// Typing the namespace is the safeguard, so clean up one at a time
+		m.setStatus("", fmt.Errorf("clean up refused: finished resources span %d namespaces on a production context; select a single namespace", len(namespaces)))
+		return nil
+	}
+	client := m.k8sClient
+
+	c := &confirmDialog{
+		title: "Clean up finished resources?",
+		details: []string{
+			renderField("Context", m.k8sClient.GetContext()),
+			renderField("Namespace", scope),
+			renderField("View", m.viewMode.String()),
+		},
+		fields: []confirmField{newInputField("older than", defaultCleanupAge)},
+		preview: func(values map[string]string) []string {
+			old, err := candidates(values["older than"])
+			if err != nil {
+				return nil
+			}
+			var affected []string
+			for _, r := range old {
+				affected = append(affected, fmt.Sprintf("%s %s/%s  %s ago", r.Kind, r.Namespace, r.Name,
+					formatDuration(time.Since(*r.EndTime))))
+			}
+			return affected
+		},
+		submit: func(values map[string]string) tea.Cmd {
+			old, err := candidates(values["older than"])
+			return func() tea.Msg {
+				if err != nil {
+					return actionResultMsg{err: err}
+				}
+				if len(old) == 0 {
+					return actionResultMsg{text: "Nothing to clean up"}
+				}
+				ctx, cancel := context.WithTimeout(context.Background(), actionTimeout)
+				defer cancel()
+
+				var errs []error
+				var entries []audit.Entry
+				for _, r := range old {
+					err := client.DeleteResource(ctx, r, "Background")
+					if err != nil {
+						errs = append(errs, fmt.Errorf("%s/%s: %w", r.Namespace, r.Name, err))
+					}
+					entries = append(entries, newAuditEntry(client, "delete", r, err))
+				}
+				if len(errs) > 0 {
+					return actionResultMsg{
+						err:   fmt.Errorf("deleted %d of %d: %w", len(old)-len(errs), len(old), errors.Join(errs...)),
+						audit: entries,
+					}
+				}
+				return actionResultMsg{text: fmt.Sprintf("Deleted %d finished resources", len(old)), audit: entries}
+			}
+		},
+	}
+	m.guardProtected(c, scope)
+	return m.openConfirm(c)
+}
+
+// parseAge parses a duration, also accepting days ("7d")
+func parseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age %q", s)
+	}
+	return d, nil
+}
+
+// addCreated shows a resource created by an action before the next refresh,
+// expanding its parent and selecting it
+func (m *Model) addCreated(r types.AsyncResource) {
+	m.resources = append(m.resources, r)
+	if r.ParentName != "" {
+		m.expanded[treeKey(r.Namespace, r.ParentName)] = true
+	}
+	m.updateFiltered()
+	id := resourceID(r)
+	for i, fr := range m.filteredCache {
+		if resourceID(fr) == id {
+			m.cursor = i
+			break
+		}
+	}
+}
+
+// actionHint is an action offered for a resource, with the permissions it needs
+type actionHint struct {
+	key     string
+	label   string
+	kind    types.ResourceKind
+	verb    string
+	podVerb string // also needed on the resource's pods, if set
+}
+
+// denied returns the permission an action lacks, or "" if it is allowed
+func (h actionHint) denied(access types.Access, namespace string) string {
+	if !access.Allowed(namespace, h.kind, h.verb) {
+		return fmt.Sprintf("cannot %s %s in %s", h.verb, h.kind, namespace)
+	}
+	if h.podVerb != "" && !access.Allowed(namespace, types.KindPod, h.podVerb) {
+		return fmt.Sprintf("cannot %s %s in %s", h.podVerb, types.KindPod, namespace)
+	}
+	return ""
+}
+
+func (h actionHint) String() string {
+	return h.key + ": " + h.label
+}
+
+// actionHints lists the actions available for a resource
+func actionHints(r types.AsyncResource) []actionHint {
+	var hints []actionHint
+	switch r.Kind {
+	case types.KindCronJob, types.KindCronWorkflow:
+		label := "suspend"
+		if r.Suspended {
+			label = "resume"
+		}
+		created := types.KindJob
+		if r.Kind == types.KindCronWorkflow {
+			created = types.KindWorkflow
+		}
+		hints = append(hints,
+			actionHint{"S", label, r.Kind, "patch", ""},
+			actionHint{"T", "run now", created, "create", ""})
+	case types.KindJob:
+		if r.Status == types.StatusSucceeded || r.Status == types.StatusFailed {
+			hints = append(hints, actionHint{"R", "re-run", types.KindJob, "create", ""})
+		}
+		hints = append(hints, actionHint{"D", "delete", types.KindJob, "delete", ""})
+	case types.KindWorkflow:
+		resubmit := actionHint{"U", "resubmit", types.KindWorkflow, "create", ""}
+		switch r.Status {
+		case types.StatusFailed:
+			hints = append(hints, actionHint{"R", "retry", types.KindWorkflow, "update", "delete"}, resubmit)
+		case types.StatusSucceeded:
+			hints = append(hints, resubmit)
+		default:
+			hints = append(hints,
+				actionHint{"X", "stop", types.KindWorkflow, "patch", ""},
+				actionHint{"K", "terminate", types.KindWorkflow, "patch", ""},
+				resubmit)
+		}
+		hints = append(hints, actionHint{"D", "delete", types.KindWorkflow, "delete", ""})
+	}
+	return hints
+}
+
+// renderStatusLine renders the latest action result, or the actions
+// available for the selected row
+func (m Model) renderStatusLine() string {
+	if time.Since(m.statusAt) < statusTTL {
+		if m.statusErr != nil {
+			return statusErrStyle.Render("✗ " + m.statusErr.Error())
+		}
+		if m.status != "" {
+			return statusOKStyle.Render("✓ " + m.status)
+		}
+	}
+	if r := m.selectedResource(); r != nil {
+		if hints := actionHints(*r); len(hints) > 0 {
+			if reason := m.readOnlyReason(); reason != "" {
+				return detailHintStyle.Render("actions disabled: read-only mode (" + reason + ")")
+			}
+			// Actions RBAC does not permit are struck through
+			line := detailHintStyle.Render("actions:")
+			for _, h := range hints {
+				style := detailHintStyle
+				if h.denied(m.access, r.Namespace) != "" {
+					style = deniedHintStyle
+				}
+				line += "  " + style.Render(h.String())
+			}
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ginbear/k8s-flowtop/internal/config"
+	"github.com/ginbear/k8s-flowtop/internal/k8s"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "7d", want: 7 * 24 * time.Hour},
+		{in: " 0d ", want: 0},
+		{in: "36h", want: 36 * time.Hour},
+		{in: "90m", want: 90 * time.Minute},
+		{in: "", wantErr: true},
+		{in: "d", wantErr: true},
+		{in: "-1d", wantErr: true},
+		{in: "-1h", wantErr: true},
+		{in: "1w", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseAge(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAge(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseAge(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// finishedAgo returns a finished resource that ended d ago
+func finishedAgo(kind types.ResourceKind, namespace, name string, status types.ResourceStatus, d time.Duration) types.AsyncResource {
+	end := time.Now().Add(-d)
+	return types.AsyncResource{Kind: kind, Namespace: namespace, Name: name, Status: status, EndTime: &end}
+}
+
+// cleanupModel returns a model listing resources on a context that is
+// production if the config says so
+func cleanupModel(cfg config.Config, view types.ViewMode, resources ...types.AsyncResource) *Model {
+	m := NewModel(&k8s.Client{}, cfg)
+	m.viewMode = view
+	m.resources = resources
+	return &m
+}
+
+// cleanupTargets returns the names the cleanup dialog would delete
+func cleanupTargets(t *testing.T, m *Model, age string) []string {
+	t.Helper()
+	if m.confirmation == nil {
+		t.Fatalf("no cleanup dialog opened: %v", m.statusErr)
+	}
+	var names []string
+	for _, line := range m.confirmation.preview(map[string]string{"older than": age}) {
+		names = append(names, strings.Fields(line)[1])
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestCleanupCandidates(t *testing.T) {
+	day := 24 * time.Hour
+	resources := []types.AsyncResource{
+		finishedAgo(types.KindJob, "batch", "old-ok", types.StatusSucceeded, 10*day),
+		finishedAgo(types.KindJob, "batch", "old-failed", types.StatusFailed, 8*day),
+		finishedAgo(types.KindJob, "batch", "recent", types.StatusSucceeded, day),
+		finishedAgo(types.KindJob, "batch", "running", types.StatusRunning, 10*day),
+		finishedAgo(types.KindCronJob, "batch", "schedule", types.StatusSucceeded, 10*day),
+		finishedAgo(types.KindWorkflow, "batch", "old-wf", types.StatusFailed, 10*day),
+		{Kind: types.KindJob, Namespace: "batch", Name: "no-end", Status: types.StatusFailed},
+	}
+
+	tests := []struct {
+		name string
+		view types.ViewMode
+		age  string
+		want []string
+	}{
+		{"all view", types.ViewAll, "7d", []string{"batch/old-failed", "batch/old-ok", "batch/old-wf"}},
+		{"jobs view skips workflows", types.ViewJobs, "7d", []string{"batch/old-failed", "batch/old-ok"}},
+		{"workflows view skips jobs", types.ViewWorkflows, "7d", []string{"batch/old-wf"}},
+		{"age cutoff", types.ViewJobs, "9d", []string{"batch/old-ok"}},
+		{"short age", types.ViewJobs, "1h", []string{"batch/old-failed", "batch/old-ok", "batch/recent"}},
+		{"invalid age", types.ViewJobs, "soon", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := cleanupModel(config.Config{}, tt.view, resources...)
+			m.cleanup()
+			if got := cleanupTargets(t, m, tt.age); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("cleanup targets = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanupSkipsDenied(t *testing.T) {
+	m := cleanupModel(config.Config{}, types.ViewAll,
+		finishedAgo(types.KindJob, "batch", "allowed", types.StatusSucceeded, 48*time.Hour),
+		finishedAgo(types.KindWorkflow, "batch", "denied", types.StatusSucceeded, 48*time.Hour),
+	)
+	m.access[types.AccessKey{Namespace: "batch", Kind: types.KindWorkflow, Verb: "delete"}] = false
+	m.cleanup()
+	if got, want := cleanupTargets(t, m, "1d"), []string{"batch/allowed"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("cleanup targets = %v, want %v", got, want)
+	}
+}
+
+func TestCleanupProductionNamespaces(t *testing.T) {
+	prod := config.Config{ProductionContexts: []string{"*"}}
+	old := 48 * time.Hour
+
+	// Several namespaces on a production context are refused
+	m := cleanupModel(prod, types.ViewAll,
+		finishedAgo(types.KindJob, "team-a", "a", types.StatusSucceeded, old),
+		finishedAgo(types.KindJob, "team-b", "b", types.StatusSucceeded, old),
+	)
+	m.cleanup()
+	if m.confirmation != nil {
+		t.Error("cleanup across namespaces opened on a production context")
+	}
+	if m.statusErr == nil || !strings.Contains(m.statusErr.Error(), "span 2 namespaces") {
+		t.Errorf("status = %v, want a refusal", m.statusErr)
+	}
+
+	// A single namespace on a production context must be typed
+	m = cleanupModel(prod, types.ViewAll, finishedAgo(types.KindJob, "team-a", "a", types.StatusSucceeded, old))
+	m.cleanup()
+	if m.confirmation == nil || m.confirmation.require != "team-a" {
+		t.Errorf("dialog = %+v, want the namespace required", m.confirmation)
+	}
+
+	// Elsewhere several namespaces are cleaned up at once
+	m = cleanupModel(config.Config{}, types.ViewAll,
+		finishedAgo(types.KindJob, "team-a", "a", types.StatusSucceeded, old),
+		finishedAgo(types.KindJob, "team-b", "b", types.StatusSucceeded, old),
+	)
+	m.cleanup()
+	if got, want := cleanupTargets(t, m, "1d"), []string{"team-a/a", "team-b/b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("cleanup targets = %v, want %v", got, want)
+	}
+}
+
+func TestCleanupNothingFinished(t *testing.T) {
+	m := cleanupModel(config.Config{}, types.ViewAll, finishedAgo(types.KindJob, "batch", "running", types.StatusRunning, time.Hour))
+	m.cleanup()
+	if m.confirmation != nil || m.statusErr == nil {
+		t.Errorf("dialog = %v, status = %v, want an error and no dialog", m.confirmation, m.statusErr)
+	}
+}
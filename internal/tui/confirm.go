@@ -0,0 +1,282 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+)
+
+// maxPreviewLines limits the affected objects listed in a dialog
+const maxPreviewLines = 10
+
+var confirmBoxStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("214")).
+	Padding(0, 1)
+
+// confirmDialog asks for confirmation before running an action,
+// optionally collecting values in fields
+type confirmDialog struct {
+	title   string
+	details []string // lines describing what will be changed
+	fields  []confirmField
+	focus   int
+	require string                                  // text to type into the "confirm" field, if set
+	preview func(values map[string]string) []string // affected objects, recomputed as fields change
+	err     string
+	submit  func(values map[string]string) tea.Cmd // runs once confirmed
+}
+
+// confirmField is an editable value in a confirmation dialog: free text,
+// or a fixed set of options cycled with ←/→
+type confirmField struct {
+	name    string
+	input   textinput.Model
+	options []string
+	choice  int
+}
+
+func newInputField(name, value string) confirmField {
+	input := textinput.New()
+	input.Prompt = ""
+	input.SetValue(value)
+	return confirmField{name: name, input: input}
+}
+
+func newChoiceField(name string, options ...string) confirmField {
+	return confirmField{name: name, options: options}
+}
+
+func (f confirmField) value() string {
+	if f.options != nil {
+		return f.options[f.choice]
+	}
+	return f.input.Value()
+}
+
+// targetDetails describes the resource an action applies to
+func (m Model) targetDetails(r types.AsyncResource) []string {
+	return []string{
+		renderField("Context", m.k8sClient.GetContext()),
+		renderField("Namespace", r.Namespace),
+		renderField("Name", r.Name),
+	}
+}
+
+// openConfirm shows a dialog, focusing its first text field
+func (m *Model) openConfirm(c *confirmDialog) tea.Cmd {
+	m.confirmation = c
+	for i := range c.fields {
+		if c.fields[i].options == nil {
+			c.focus = i
+			return c.fields[i].input.Focus()
+		}
+	}
+	return nil
+}
+
+// confirm opens a confirmation dialog for an action on a resource
+func (m *Model) confirm(title string, r types.AsyncResource, action tea.Cmd, extra ...string) {
+	c := &confirmDialog{
+		title:   title,
+		details: append(m.targetDetails(r), extra...),
+		submit:  func(map[string]string) tea.Cmd { return action },
+	}
+	m.guardProtected(c, r.Namespace)
+	m.openConfirm(c)
+}
+
+// confirmAffected opens a confirmation dialog for an action on a resource
+// that lists the objects it affects
+func (m *Model) confirmAffected(title string, r types.AsyncResource, action tea.Cmd, affected []string, extra ...string) {
+	c := &confirmDialog{
+		title:   title,
+		details: append(m.targetDetails(r), extra...),
+		preview: func(map[string]string) []string { return affected },
+		submit:  func(map[string]string) tea.Cmd { return action },
+	}
+	m.guardProtected(c, r.Namespace)
+	m.openConfirm(c)
+}
+
+// confirmWithFields opens a confirmation dialog with text fields
+// prefilled from values; submit receives the edited values
+func (m *Model) confirmWithFields(title string, r types.AsyncResource, values []types.NodeIO, submit func(map[string]string) tea.Cmd, extra ...string) tea.Cmd {
+	var fields []confirmField
+	for _, v := range values {
+		fields = append(fields, newInputField(v.Name, v.Value))
+	}
+	c := &confirmDialog{
+		title:   title,
+		details: append(m.targetDetails(r), extra...),
+		fields:  fields,
+		submit:  submit,
+	}
+	m.guardProtected(c, r.Namespace)
+	return m.openConfirm(c)
+}
+
+// guardProtected makes a dialog require typing the namespace on
+// production contexts
+func (m Model) guardProtected(c *confirmDialog, namespace string) {
+	if !m.cfg.IsProduction(m.k8sClient.GetContext()) {
+		return
+	}
+	c.require = namespace
+	c.details = append(c.details, statusErrStyle.Render("⚠ production context")+"\n")
+	field := newInputField("confirm", "")
+	field.input.Placeholder = fmt.Sprintf("type %q", namespace)
+	field.input.Width = len(field.input.Placeholder)
+	c.fields = append(c.fields, field)
+}
+
+// updateConfirm handles keys while a confirmation dialog is open.
+// Without fields y/n answer the dialog; with fields, keys edit the
+// focused field and enter/esc answer it.
+func (m *Model) updateConfirm(msg tea.KeyMsg) tea.Cmd {
+	c := m.confirmation
+	switch msg.String() {
+	case "enter":
+		return m.submitConfirm()
+	case "esc":
+		m.confirmation = nil
+		m.setStatus("Cancelled", nil)
+		return nil
+	}
+
+	if len(c.fields) == 0 {
+		switch msg.String() {
+		case "y", "Y":
+			return m.submitConfirm()
+		case "n", "N", "q":
+			m.confirmation = nil
+			m.setStatus("Cancelled", nil)
+		}
+		return nil
+	}
+
+	switch msg.String() {
+	case "tab", "down":
+		return c.focusField(c.focus + 1)
+	case "shift+tab", "up":
+		return c.focusField(c.focus - 1)
+	}
+
+	f := &c.fields[c.focus]
+	if f.options != nil {
+		switch msg.String() {
+		case "right", "l", " ":
+			f.choice = (f.choice + 1) % len(f.options)
+		case "left", "h":
+			f.choice = (f.choice - 1 + len(f.options)) % len(f.options)
+		}
+		return nil
+	}
+	var cmd tea.Cmd
+	f.input, cmd = f.input.Update(msg)
+	return cmd
+}
+
+// values returns the current field values by name
+func (c *confirmDialog) values() map[string]string {
+	values := make(map[string]string, len(c.fields))
+	for _, f := range c.fields {
+		values[f.name] = f.value()
+	}
+	return values
+}
+
+// submitConfirm closes the dialog and runs its action, unless the
+// required confirmation text was not typed
+func (m *Model) submitConfirm() tea.Cmd {
+	c := m.confirmation
+	values := c.values()
+	if c.require != "" {
+		if values["confirm"] != c.require {
+			c.err = fmt.Sprintf("type %q to confirm", c.require)
+			return nil
+		}
+		delete(values, "confirm")
+	}
+	m.confirmation = nil
+	return c.submit(values)
+}
+
+// focusField moves the input focus, wrapping around
+func (c *confirmDialog) focusField(i int) tea.Cmd {
+	c.fields[c.focus].input.Blur()
+	c.focus = (i + len(c.fields)) % len(c.fields)
+	if c.fields[c.focus].options != nil {
+		return nil
+	}
+	return c.fields[c.focus].input.Focus()
+}
+
+// renderConfirm renders the open confirmation dialog
+func (m Model) renderConfirm() string {
+	c := m.confirmation
+
+	var b strings.Builder
+	b.WriteString(detailTitleStyle.Render(c.title))
+	b.WriteString("\n")
+	for _, line := range c.details {
+		b.WriteString(line)
+	}
+
+	if c.preview != nil {
+		affected := c.preview(c.values())
+		b.WriteString("\n")
+		b.WriteString(detailHintStyle.Render(fmt.Sprintf("Affected objects (%d)", len(affected))))
+		b.WriteString("\n")
+		for i, line := range affected {
+			if i == maxPreviewLines {
+				b.WriteString(detailHintStyle.Render(fmt.Sprintf("  ...and %d more", len(affected)-i)))
+				b.WriteString("\n")
+				break
+			}
+			b.WriteString("  " + line + "\n")
+		}
+	}
+
+	if len(c.fields) > 0 {
+		b.WriteString("\n")
+		nameW := 0
+		for _, f := range c.fields {
+			nameW = max(nameW, len(f.name)+1)
+		}
+		for i, f := range c.fields {
+			value := f.input.View()
+			if f.options != nil {
+				value = subTabInactiveStyle.Render("‹ " + f.value() + " ›")
+				if i == c.focus {
+					value = subTabActiveStyle.Render("‹ " + f.value() + " ›")
+				}
+			}
+			b.WriteString(labelStyle.Width(nameW+1).Render(f.name+":") + " " + value + "\n")
+		}
+	}
+	if c.err != "" {
+		b.WriteString(statusErrStyle.Render(c.err))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if len(c.fields) > 0 {
+		hints := []string{"tab: next field"}
+		for _, f := range c.fields {
+			if f.options != nil {
+				hints = append(hints, "←/→: change option")
+				break
+			}
+		}
+		hints = append(hints, "enter: confirm", "esc: cancel")
+		b.WriteString(detailHintStyle.Render(strings.Join(hints, "  ")))
+	} else {
+		b.WriteString(detailHintStyle.Render("y/enter: confirm  n/esc: cancel"))
+	}
+	return confirmBoxStyle.Render(b.String())
+}
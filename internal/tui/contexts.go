@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ginbear/k8s-flowtop/internal/k8s"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+)
+
+// contextPicker lists the kubeconfig contexts to switch to
+type contextPicker struct {
+	contexts []string
+	cursor   int
+}
+
+// clientMsg carries the client for a newly selected context
+type clientMsg struct {
+	client *k8s.Client
+	err    error
+}
+
+// openContextPicker shows the contexts, with the current one selected
+func (m *Model) openContextPicker() {
+	contexts := m.k8sClient.Contexts()
+	if len(contexts) == 0 {
+		m.setStatus("", fmt.Errorf("no contexts in kubeconfig"))
+		return
+	}
+	p := &contextPicker{contexts: contexts}
+	for i, name := range contexts {
+		if name == m.k8sClient.GetContext() {
+			p.cursor = i
+		}
+	}
+	m.contextPicker = p
+}
+
+// updateContextPicker handles keys while the context picker is open
+func (m *Model) updateContextPicker(msg tea.KeyMsg) tea.Cmd {
+	p := m.contextPicker
+	switch msg.String() {
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j":
+		if p.cursor < len(p.contexts)-1 {
+			p.cursor++
+		}
+	case "esc", "q", "c":
+		m.contextPicker = nil
+	case "enter":
+		m.contextPicker = nil
+		name := p.contexts[p.cursor]
+		if name == m.k8sClient.GetContext() {
+			return nil
+		}
+		m.setStatus("Connecting to "+name+"...", nil)
+		namespace := m.k8sClient.GetNamespace()
+		return func() tea.Msg {
+			client, err := k8s.NewClientForContext(namespace, name)
+			return clientMsg{client: client, err: err}
+		}
+	}
+	return nil
+}
+
+// setClient switches to a new cluster connection, dropping everything
+// read through the previous one
+func (m *Model) setClient(client *k8s.Client) tea.Cmd {
+	if m.eventCancel != nil {
+		m.eventCancel()
+		m.eventCancel = nil
+	}
+	if m.showDetail {
+		m.detail.stopLogs()
+		m.showDetail = false
+	}
+
+	m.k8sClient = client
+	m.resources = nil
+	m.kubeEvents = nil
+	m.kubeEventRows = nil
+	m.cursor = 0
+	m.eventCursor = 0
+	m.expanded = make(map[string]bool)
+	m.access = make(types.Access)
+	m.accessChecked = make(map[string]bool)
+	m.forbidden = nil
+	m.confirmation = nil
+	m.updateFiltered()
+	m.setStatus("Switched to context "+client.GetContext(), nil)
+
+	cmds := []tea.Cmd{m.discoverAPIs(), m.fetchResources(), m.checkReadAccess()}
+	if m.viewMode == types.ViewKubeEvents {
+		cmds = append(cmds, m.startEventStream())
+	}
+	return tea.Batch(cmds...)
+}
+
+// apisMsg carries the kinds a cluster serves
+type apisMsg struct {
+	client *k8s.Client // client discovery ran through
+	apis   k8s.Discovery
+}
+
+// discoverAPIs finds the kinds the cluster serves in the background;
+// until it returns, every kind is treated as installed
+func (m Model) discoverAPIs() tea.Cmd {
+	client := m.k8sClient
+	return func() tea.Msg {
+		return apisMsg{client: client, apis: client.Discover()}
+	}
+}
+
+// absentKinds returns the kinds of a view the cluster does not serve
+func (m Model) absentKinds(v types.ViewMode) []types.ResourceKind {
+	apis := m.k8sClient.APIs()
+	var absent []types.ResourceKind
+	for _, kind := range viewKinds(v) {
+		if kind != types.KindEvent && !apis.Installed(kind) {
+			absent = append(absent, kind)
+		}
+	}
+	return absent
+}
+
+// renderContextPicker renders the context list
+func (m Model) renderContextPicker(maxRows int) string {
+	p := m.contextPicker
+
+	var b strings.Builder
+	b.WriteString(detailTitleStyle.Render("Switch context"))
+	b.WriteString("\n")
+
+	start := 0
+	if p.cursor >= maxRows {
+		start = p.cursor - maxRows + 1
+	}
+	end := min(start+maxRows, len(p.contexts))
+	for i := start; i < end; i++ {
+		name := p.contexts[i]
+		marker := "  "
+		if name == m.k8sClient.GetContext() {
+			marker = "● "
+		}
+		line := marker + name
+		if m.cfg.ReadOnlyReason(name) != "" {
+			line += "  " + detailHintStyle.Render("[read-only]")
+		} else if m.cfg.IsProduction(name) {
+			line += "  " + warnBadgeStyle.Render("PROTECTED")
+		}
+		if i == p.cursor {
+			line = selectedRowStyle.Render(marker+name) + strings.TrimPrefix(line, marker+name)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(detailHintStyle.Render("↑/↓: select  enter: switch  esc: cancel"))
+	return confirmBoxStyle.Render(b.String())
+}
@@ -0,0 +1,488 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+)
+
+// Graph geometry
+const (
+	dagBoxHeight  = 3 // top border, label, bottom border
+	dagEdgeHeight = 3 // rows of connectors between layers
+	dagMinBoxW    = 12
+	dagMaxBoxW    = 28
+	dagGap        = 2
+)
+
+// dummyPrefix marks virtual nodes routing edges that skip layers
+const dummyPrefix = "\x00"
+
+// dagGraph is a layered layout of a workflow DAG
+type dagGraph struct {
+	nodes  map[string]types.DAGNode
+	layers [][]string          // node IDs per layer, in drawing order
+	pos    map[string][2]int   // node ID -> (layer, index)
+	edges  map[string][]string // edges after dummy insertion
+	boxW   int
+	cols   int // maximum nodes in a layer
+}
+
+// layoutDAG assigns nodes to layers by longest path from the roots and
+// orders each layer by the position of its parents
+func layoutDAG(nodes []types.DAGNode) *dagGraph {
+	g := &dagGraph{
+		nodes: make(map[string]types.DAGNode),
+		pos:   make(map[string][2]int),
+		edges: make(map[string][]string),
+		boxW:  dagMinBoxW,
+	}
+	for _, n := range nodes {
+		g.nodes[n.ID] = n
+		// Borders, padding and the phase icon take 6 columns
+		if w := lipgloss.Width(n.Name+attemptsSuffix(n)) + 6; w > g.boxW {
+			g.boxW = min(w, dagMaxBoxW)
+		}
+	}
+
+	// Incoming edge counts, ignoring children that were filtered out
+	indeg := make(map[string]int)
+	for id, n := range g.nodes {
+		for _, c := range n.Children {
+			if _, ok := g.nodes[c]; ok && c != id {
+				indeg[c]++
+			}
+		}
+	}
+
+	// Longest-path layering in topological order (Kahn)
+	layer := make(map[string]int)
+	var queue []string
+	for id := range g.nodes {
+		if indeg[id] == 0 {
+			queue = append(queue, id)
+		}
+	}
+	sort.Strings(queue)
+	visited := 0
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		visited++
+		for _, c := range g.nodes[id].Children {
+			if _, ok := g.nodes[c]; !ok || c == id {
+				continue
+			}
+			layer[c] = max(layer[c], layer[id]+1)
+			indeg[c]--
+			if indeg[c] == 0 {
+				queue = append(queue, c)
+			}
+		}
+	}
+	// Nodes left in a cycle stay on layer 0 rather than disappearing
+	if visited < len(g.nodes) {
+		for id := range g.nodes {
+			if _, ok := layer[id]; !ok {
+				layer[id] = 0
+			}
+		}
+	}
+
+	depth := 0
+	for _, l := range layer {
+		depth = max(depth, l+1)
+	}
+	g.layers = make([][]string, depth)
+
+	// Insert dummy nodes so every edge spans exactly one layer
+	ids := make([]string, 0, len(g.nodes))
+	for id := range g.nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		g.layers[layer[id]] = append(g.layers[layer[id]], id)
+	}
+	for _, id := range ids {
+		for _, c := range g.nodes[id].Children {
+			if _, ok := g.nodes[c]; !ok || c == id {
+				continue
+			}
+			from := id
+			for l := layer[id] + 1; l < layer[c]; l++ {
+				dummy := fmt.Sprintf("%s%s->%s@%d", dummyPrefix, id, c, l)
+				g.layers[l] = append(g.layers[l], dummy)
+				g.edges[from] = append(g.edges[from], dummy)
+				from = dummy
+			}
+			if layer[c] > layer[id] {
+				g.edges[from] = append(g.edges[from], c)
+			}
+		}
+	}
+
+	// Order layers by the mean position of their parents (barycenter)
+	parents := make(map[string][]string)
+	for from, tos := range g.edges {
+		for _, to := range tos {
+			parents[to] = append(parents[to], from)
+		}
+	}
+	for l := range g.layers {
+		if l > 0 {
+			center := make(map[string]float64)
+			for _, id := range g.layers[l] {
+				ps := parents[id]
+				if len(ps) == 0 {
+					continue
+				}
+				sum := 0.0
+				for _, p := range ps {
+					sum += float64(g.pos[p][1])
+				}
+				center[id] = sum / float64(len(ps))
+			}
+			sort.SliceStable(g.layers[l], func(i, j int) bool {
+				return center[g.layers[l][i]] < center[g.layers[l][j]]
+			})
+		}
+		for i, id := range g.layers[l] {
+			g.pos[id] = [2]int{l, i}
+		}
+		g.cols = max(g.cols, len(g.layers[l]))
+	}
+
+	return g
+}
+
+// isDummy reports whether id is a virtual edge-routing node
+func isDummy(id string) bool {
+	return strings.HasPrefix(id, dummyPrefix)
+}
+
+// slotW is the horizontal space taken by one node
+func (g *dagGraph) slotW() int {
+	return g.boxW + dagGap
+}
+
+// width returns the canvas width
+func (g *dagGraph) width() int {
+	return g.cols * g.slotW()
+}
+
+// height returns the canvas height
+func (g *dagGraph) height() int {
+	if len(g.layers) == 0 {
+		return 0
+	}
+	return len(g.layers)*(dagBoxHeight+dagEdgeHeight) - dagEdgeHeight
+}
+
+// origin returns the top-left corner of a node's box; layers are centered
+func (g *dagGraph) origin(id string) (x, y int) {
+	p := g.pos[id]
+	offset := (g.cols - len(g.layers[p[0]])) * g.slotW() / 2
+	return offset + p[1]*g.slotW(), p[0] * (dagBoxHeight + dagEdgeHeight)
+}
+
+// center returns the x coordinate of a node's vertical axis
+func (g *dagGraph) center(id string) int {
+	x, _ := g.origin(id)
+	return x + g.boxW/2
+}
+
+// neighbor returns the node reached by moving from id by (dLayer, dIndex),
+// skipping dummy nodes. Moving between layers picks the nearest node by x.
+func (g *dagGraph) neighbor(id string, dLayer, dIndex int) string {
+	p, ok := g.pos[id]
+	if !ok {
+		return g.first()
+	}
+
+	if dLayer == 0 {
+		row := g.layers[p[0]]
+		for i := p[1] + dIndex; i >= 0 && i < len(row); i += dIndex {
+			if !isDummy(row[i]) {
+				return row[i]
+			}
+		}
+		return id
+	}
+
+	cx := g.center(id)
+	for l := p[0] + dLayer; l >= 0 && l < len(g.layers); l += dLayer {
+		best, bestDist := "", 0
+		for _, cand := range g.layers[l] {
+			if isDummy(cand) {
+				continue
+			}
+			dist := g.center(cand) - cx
+			if dist < 0 {
+				dist = -dist
+			}
+			if best == "" || dist < bestDist {
+				best, bestDist = cand, dist
+			}
+		}
+		if best != "" {
+			return best
+		}
+	}
+	return id
+}
+
+// first returns the first real node in drawing order
+func (g *dagGraph) first() string {
+	for _, row := range g.layers {
+		for _, id := range row {
+			if !isDummy(id) {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+// Connector direction bits
+const (
+	dirUp = 1 << iota
+	dirDown
+	dirLeft
+	dirRight
+)
+
+var connectorGlyphs = map[int]rune{
+	dirUp:                                '│',
+	dirDown:                              '│',
+	dirUp | dirDown:                      '│',
+	dirLeft:                              '─',
+	dirRight:                             '─',
+	dirLeft | dirRight:                   '─',
+	dirUp | dirRight:                     '└',
+	dirUp | dirLeft:                      '┘',
+	dirDown | dirRight:                   '┌',
+	dirDown | dirLeft:                    '┐',
+	dirUp | dirDown | dirRight:           '├',
+	dirUp | dirDown | dirLeft:            '┤',
+	dirLeft | dirRight | dirDown:         '┬',
+	dirLeft | dirRight | dirUp:           '┴',
+	dirUp | dirDown | dirLeft | dirRight: '┼',
+}
+
+// Cell styles on the canvas
+const (
+	cellNone = iota
+	cellEdge
+	cellSelected
+	cellPhaseBase // cellPhaseBase + phase index
+)
+
+// dagCanvas is a grid of runes with a style per cell
+type dagCanvas struct {
+	w, h   int
+	runes  [][]rune
+	styles [][]int
+	dirs   [][]int
+}
+
+func newDAGCanvas(w, h int) *dagCanvas {
+	c := &dagCanvas{w: w, h: h}
+	c.runes = make([][]rune, h)
+	c.styles = make([][]int, h)
+	c.dirs = make([][]int, h)
+	for y := 0; y < h; y++ {
+		c.runes[y] = []rune(strings.Repeat(" ", w))
+		c.styles[y] = make([]int, w)
+		c.dirs[y] = make([]int, w)
+	}
+	return c
+}
+
+func (c *dagCanvas) set(x, y int, r rune, style int) {
+	if x < 0 || y < 0 || x >= c.w || y >= c.h {
+		return
+	}
+	c.runes[y][x] = r
+	c.styles[y][x] = style
+}
+
+// connect merges connector directions into a cell
+func (c *dagCanvas) connect(x, y, dirs int) {
+	if x < 0 || y < 0 || x >= c.w || y >= c.h {
+		return
+	}
+	c.dirs[y][x] |= dirs
+	c.set(x, y, connectorGlyphs[c.dirs[y][x]], cellEdge)
+}
+
+// dagPhases lists the phase styles in canvas order
+var dagPhases = []string{"Succeeded", "Running", "Failed", "Pending", "Omitted", ""}
+
+func phaseCell(phase string) int {
+	if phase == "Error" {
+		phase = "Failed"
+	}
+	for i, p := range dagPhases {
+		if p == phase {
+			return cellPhaseBase + i
+		}
+	}
+	return cellPhaseBase + len(dagPhases) - 1
+}
+
+func phaseIcon(phase string) string {
+	switch phase {
+	case "Succeeded":
+		return "✓"
+	case "Running":
+		return "●"
+	case "Failed", "Error":
+		return "✗"
+	case "Pending":
+		return "○"
+	case "Omitted":
+		return "⊘"
+	default:
+		return "?"
+	}
+}
+
+func phaseColor(phase string) lipgloss.Color {
+	switch phase {
+	case "Succeeded":
+		return lipgloss.Color("34")
+	case "Running":
+		return lipgloss.Color("33")
+	case "Failed", "Error":
+		return lipgloss.Color("196")
+	case "Omitted":
+		return lipgloss.Color("240")
+	default:
+		return lipgloss.Color("241")
+	}
+}
+
+// draw renders the graph onto a canvas, highlighting the selected node
+func (g *dagGraph) draw(selected string) *dagCanvas {
+	c := newDAGCanvas(g.width(), g.height())
+
+	for _, row := range g.layers {
+		for _, id := range row {
+			x, y := g.origin(id)
+			cx := g.center(id)
+			if isDummy(id) {
+				for dy := 0; dy < dagBoxHeight; dy++ {
+					c.connect(cx, y+dy, dirUp|dirDown)
+				}
+				continue
+			}
+			g.drawBox(c, id, x, y, id == selected)
+		}
+	}
+
+	for from, tos := range g.edges {
+		_, fy := g.origin(from)
+		fx := g.center(from)
+		top := fy + dagBoxHeight
+		c.connect(fx, top, dirUp|dirDown)
+		for _, to := range tos {
+			tx := g.center(to)
+			switch {
+			case tx == fx:
+				c.connect(fx, top+1, dirUp|dirDown)
+			case tx > fx:
+				c.connect(fx, top+1, dirUp|dirRight)
+				for x := fx + 1; x < tx; x++ {
+					c.connect(x, top+1, dirLeft|dirRight)
+				}
+				c.connect(tx, top+1, dirLeft|dirDown)
+			default:
+				c.connect(fx, top+1, dirUp|dirLeft)
+				for x := tx + 1; x < fx; x++ {
+					c.connect(x, top+1, dirLeft|dirRight)
+				}
+				c.connect(tx, top+1, dirRight|dirDown)
+			}
+			if isDummy(to) {
+				c.connect(tx, top+2, dirUp|dirDown)
+			} else {
+				c.set(tx, top+2, '▼', cellEdge)
+			}
+		}
+	}
+
+	return c
+}
+
+func (g *dagGraph) drawBox(c *dagCanvas, id string, x, y int, selected bool) {
+	n := g.nodes[id]
+	style := phaseCell(n.Phase)
+	border := style
+	tl, tr, bl, br, hz, vt := '╭', '╮', '╰', '╯', '─', '│'
+	if selected {
+		border = cellSelected
+		tl, tr, bl, br, hz, vt = '┏', '┓', '┗', '┛', '━', '┃'
+	}
+
+	w := g.boxW
+	c.set(x, y, tl, border)
+	c.set(x+w-1, y, tr, border)
+	c.set(x, y+2, bl, border)
+	c.set(x+w-1, y+2, br, border)
+	for i := 1; i < w-1; i++ {
+		c.set(x+i, y, hz, border)
+		c.set(x+i, y+2, hz, border)
+	}
+	c.set(x, y+1, vt, border)
+	c.set(x+w-1, y+1, vt, border)
+
+	suffix := attemptsSuffix(n)
+	label := []rune(phaseIcon(n.Phase) + " " + truncate(n.Name, w-6-len([]rune(suffix))) + suffix)
+	for i, r := range label {
+		if i >= w-4 {
+			break
+		}
+		c.set(x+2+i, y+1, r, style)
+	}
+}
+
+// attemptsSuffix marks Retry nodes with their attempt count
+func attemptsSuffix(n types.DAGNode) string {
+	if n.Attempts > 1 {
+		return fmt.Sprintf(" ×%d", n.Attempts)
+	}
+	return ""
+}
+
+// render converts the visible window of the canvas to styled text
+func (c *dagCanvas) render(xOff, width int) string {
+	palette := map[int]lipgloss.Style{
+		cellNone:     lipgloss.NewStyle(),
+		cellEdge:     lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+		cellSelected: lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Bold(true),
+	}
+	for i, p := range dagPhases {
+		palette[cellPhaseBase+i] = lipgloss.NewStyle().Foreground(phaseColor(p))
+	}
+
+	end := min(xOff+width, c.w)
+	lines := make([]string, c.h)
+	for y := 0; y < c.h; y++ {
+		var b strings.Builder
+		runStart := xOff
+		for x := xOff; x <= end; x++ {
+			if x == end || c.styles[y][x] != c.styles[y][runStart] {
+				if x > runStart {
+					b.WriteString(palette[c.styles[y][runStart]].Render(string(c.runes[y][runStart:x])))
+				}
+				runStart = x
+			}
+		}
+		lines[y] = strings.TrimRight(b.String(), " ")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ginbear/k8s-flowtop/internal/types"
+)
+
+// realLayers returns the layers of a graph without dummy nodes
+func realLayers(g *dagGraph) [][]string {
+	layers := make([][]string, len(g.layers))
+	for l, ids := range g.layers {
+		layers[l] = []string{}
+		for _, id := range ids {
+			if !isDummy(id) {
+				layers[l] = append(layers[l], id)
+			}
+		}
+	}
+	return layers
+}
+
+func TestLayoutDAG(t *testing.T) {
+	tests := []struct {
+		name        string
+		nodes       []types.DAGNode
+		want        [][]string
+		wantDummies int
+	}{
+		{
+			name: "empty",
+			want: [][]string{},
+		},
+		{
+			name: "diamond",
+			nodes: []types.DAGNode{
+				{ID: "a", Children: []string{"b", "c"}},
+				{ID: "b", Children: []string{"d"}},
+				{ID: "c", Children: []string{"d"}},
+				{ID: "d"},
+			},
+			want: [][]string{{"a"}, {"b", "c"}, {"d"}},
+		},
+		{
+			name: "longest path with a dummy for the skipped layer",
+			nodes: []types.DAGNode{
+				{ID: "a", Children: []string{"b", "c"}},
+				{ID: "b", Children: []string{"c"}},
+				{ID: "c"},
+			},
+			want:        [][]string{{"a"}, {"b"}, {"c"}},
+			wantDummies: 1,
+		},
+		{
+			name: "filtered children and self loops are ignored",
+			nodes: []types.DAGNode{
+				{ID: "a", Children: []string{"a", "gone", "b"}},
+				{ID: "b"},
+			},
+			want: [][]string{{"a"}, {"b"}},
+		},
+		{
+			name: "cycle stays on the first layer",
+			nodes: []types.DAGNode{
+				{ID: "root"},
+				{ID: "x", Children: []string{"y"}},
+				{ID: "y", Children: []string{"x"}},
+			},
+			want: [][]string{{"root", "x", "y"}},
+		},
+		{
+			name: "children ordered by their parents",
+			nodes: []types.DAGNode{
+				{ID: "a", Children: []string{"z"}},
+				{ID: "b", Children: []string{"y"}},
+				{ID: "y"},
+				{ID: "z"},
+			},
+			want: [][]string{{"a", "b"}, {"z", "y"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := layoutDAG(tt.nodes)
+			if got := realLayers(g); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("layers = %v, want %v", got, tt.want)
+			}
+
+			dummies := 0
+			for l, ids := range g.layers {
+				for i, id := range ids {
+					if isDummy(id) {
+						dummies++
+					}
+					if g.pos[id] != [2]int{l, i} {
+						t.Errorf("pos[%q] = %v, want %v", id, g.pos[id], [2]int{l, i})
+					}
+				}
+			}
+			if dummies != tt.wantDummies {
+				t.Errorf("dummy nodes = %d, want %d", dummies, tt.wantDummies)
+			}
+
+			// Every edge spans exactly one layer
+			for from, tos := range g.edges {
+				for _, to := range tos {
+					if g.pos[to][0] != g.pos[from][0]+1 {
+						t.Errorf("edge %q -> %q spans layers %d to %d", from, to, g.pos[from][0], g.pos[to][0])
+					}
+				}
+			}
+		})
+	}
+}
@@ -1,10 +1,20 @@
 package tui
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ginbear/k8s-flowtop/internal/k8s"
+	"github.com/ginbear/k8s-flowtop/internal/kinds"
 	"github.com/ginbear/k8s-flowtop/internal/types"
 )
 
@@ -12,8 +22,7 @@ var (
 	detailBoxStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(lipgloss.Color("99")).
-			Padding(1, 2).
-			Width(100)
+			Padding(0, 1)
 
 	detailTitleStyle = lipgloss.NewStyle().
 				Bold(true).
@@ -26,19 +35,624 @@ var (
 
 	valueStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("255"))
+
+	detailHintStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("241"))
+
+	subTabActiveStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("229")).
+				Background(lipgloss.Color("99")).
+				Padding(0, 1)
+
+	subTabInactiveStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("250")).
+				Background(lipgloss.Color("236")).
+				Padding(0, 1)
 )
 
-// RenderDetail renders the detail view for a resource
-func RenderDetail(r types.AsyncResource, width, height int) string {
+// DetailTab identifies a sub-tab of the detail view
+type DetailTab int
+
+const (
+	DetailOverview DetailTab = iota
+	DetailDAG
+	DetailTimeline
+	DetailPods
+	DetailEvents
+	DetailLogs
+	DetailYAML
+	detailTabCount
+)
+
+func (t DetailTab) String() string {
+	switch t {
+	case DetailDAG:
+		return "DAG"
+	case DetailTimeline:
+		return "Timeline"
+	case DetailPods:
+		return "Pods"
+	case DetailEvents:
+		return "Events"
+	case DetailLogs:
+		return "Logs"
+	case DetailYAML:
+		return "YAML"
+	default:
+		return "Overview"
+	}
+}
+
+// DetailKeyMap defines the keybindings of the detail view
+type DetailKeyMap struct {
+	Close       key.Binding
+	NextTab     key.Binding
+	PrevTab     key.Binding
+	JumpTab     key.Binding
+	Top         key.Binding
+	Bottom      key.Binding
+	Search      key.Binding
+	NextMatch   key.Binding
+	PrevMatch   key.Binding
+	ToggleScope key.Binding
+	ToggleJSON  key.Binding
+	Viewport    viewport.KeyMap
+}
+
+var detailKeys = DetailKeyMap{
+	Close: key.NewBinding(
+		key.WithKeys("esc", "enter", "q"),
+		key.WithHelp("esc", "close"),
+	),
+	NextTab: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "next tab"),
+	),
+	PrevTab: key.NewBinding(
+		key.WithKeys("shift+tab"),
+		key.WithHelp("shift+tab", "prev tab"),
+	),
+	JumpTab: key.NewBinding(
+		key.WithKeys("1", "2", "3", "4", "5", "6", "7"),
+		key.WithHelp("1-7", "jump to tab"),
+	),
+	Top: key.NewBinding(
+		key.WithKeys("g", "home"),
+		key.WithHelp("g", "top"),
+	),
+	Bottom: key.NewBinding(
+		key.WithKeys("G", "end"),
+		key.WithHelp("G", "bottom"),
+	),
+	Search: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "search"),
+	),
+	NextMatch: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next match"),
+	),
+	PrevMatch: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "prev match"),
+	),
+	ToggleScope: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "full/spec/status"),
+	),
+	ToggleJSON: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "yaml/json"),
+	),
+	Viewport: viewport.KeyMap{
+		PageDown: key.NewBinding(
+			key.WithKeys("pgdown", " "),
+			key.WithHelp("pgdn", "page down"),
+		),
+		PageUp: key.NewBinding(
+			key.WithKeys("pgup"),
+			key.WithHelp("pgup", "page up"),
+		),
+		HalfPageDown: key.NewBinding(
+			key.WithKeys("ctrl+d"),
+			key.WithHelp("ctrl+d", "½ page down"),
+		),
+		HalfPageUp: key.NewBinding(
+			key.WithKeys("ctrl+u"),
+			key.WithHelp("ctrl+u", "½ page up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+	},
+}
+
+// objectMsg carries the raw object fetched for the YAML tab
+type objectMsg struct {
+	id     string
+	object map[string]interface{}
+	err    error
+}
+
+// detailView is the scrollable, tabbed detail screen for a single resource
+type detailView struct {
+	client   *k8s.Client
+	resource types.AsyncResource
+	gone     bool // resource no longer exists in the cluster
+	tab      DetailTab
+	viewport viewport.Model
+	width    int
+	height   int
+
+	// DAG tab
+	dagSelected string // ID of the highlighted node
+
+	// Timeline tab
+	frame     int  // animation frame for running nodes
+	animating bool // an animation tick is scheduled
+
+	// Pods (Pods and Logs tabs)
+	pods    []types.PodInfo
+	podsErr error
+
+	// Events tab
+	events    []types.EventInfo
+	eventsErr error
+
+	// Logs tab
+	logPod       string // selected pod name
+	logContainer string
+	logFollow    bool
+	logPrevious  bool
+	logWrap      bool
+	logLines     []string
+	logErr       error
+	logGen       int    // generation of the active stream; stale batches are dropped
+	logKey       string // options of the active stream
+	logCancel    context.CancelFunc
+
+	// YAML tab
+	object    map[string]interface{}
+	objectErr error
+	scope     objectScope
+	asJSON    bool
+
+	// Search (text tabs only)
+	search     textinput.Model
+	searching  bool
+	query      string
+	matchLines []int
+	matchIdx   int
+}
+
+// newDetailView creates a detail view for the given resource
+func newDetailView(client *k8s.Client, r types.AsyncResource, width, height int) detailView {
+	search := textinput.New()
+	search.Prompt = "/"
+	search.Placeholder = "search"
+
+	d := detailView{
+		client:    client,
+		resource:  r,
+		viewport:  viewport.New(0, 0),
+		search:    search,
+		logFollow: true,
+	}
+	d.viewport.KeyMap = detailKeys.Viewport
+	d.setSize(width, height)
+	return d
+}
+
+// setSize resizes the view to the terminal size
+func (d *detailView) setSize(width, height int) {
+	if width <= 0 {
+		width = 100
+	}
+	if height <= 0 {
+		height = 30
+	}
+	d.width = width
+	d.height = height
+
+	// Box border (2) + padding (2) horizontally;
+	// border (2) + title, tabs, blank, blank, footer (5) vertically
+	d.viewport.Width = max(width-4, 20)
+	d.viewport.Height = max(height-7, 3)
+	d.refreshContent()
+}
+
+// setResource replaces the displayed resource with a fresh copy,
+// keeping the scroll position, and reloads data for the active tab
+func (d *detailView) setResource(r types.AsyncResource) tea.Cmd {
+	d.resource = r
+	d.gone = false
+	d.refreshContent()
+	return d.loadTab()
+}
+
+// markGone flags the resource as deleted from the cluster
+func (d *detailView) markGone() {
+	d.gone = true
+}
+
+// setTab switches to the given sub-tab, scrolls to the top and loads its data
+func (d *detailView) setTab(t DetailTab) tea.Cmd {
+	t = (t + detailTabCount) % detailTabCount
+	if d.tab == DetailLogs && t != DetailLogs {
+		d.stopLogs()
+	}
+	d.tab = t
+	d.query = ""
+	d.refreshContent()
+	d.viewport.GotoTop()
+	return d.loadTab()
+}
+
+// loadTab returns a command fetching the data the active tab needs
+func (d *detailView) loadTab() tea.Cmd {
+	switch d.tab {
+	case DetailTimeline:
+		return d.startAnimation()
+	case DetailPods, DetailLogs:
+		return d.fetchPods()
+	case DetailEvents:
+		return d.fetchEvents()
+	case DetailYAML:
+		return d.fetchObject()
+	default:
+		return nil
+	}
+}
+
+// startAnimation schedules animation ticks while running nodes are on screen
+func (d *detailView) startAnimation() tea.Cmd {
+	if d.animating || !hasRunningNodes(d.resource.DAGNodes) {
+		return nil
+	}
+	d.animating = true
+	return animTickCmd()
+}
+
+// animate advances the animation by one frame
+func (d *detailView) animate() tea.Cmd {
+	if d.tab != DetailTimeline || !hasRunningNodes(d.resource.DAGNodes) {
+		d.animating = false
+		return nil
+	}
+	d.frame++
+	d.refreshContent()
+	return animTickCmd()
+}
+
+// fetchObject fetches the raw object for the YAML tab
+func (d *detailView) fetchObject() tea.Cmd {
+	client := d.client
+	r := d.resource
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		obj, err := client.GetObject(ctx, r)
+		return objectMsg{id: resourceID(r), object: obj, err: err}
+	}
+}
+
+// setObject stores a fetched raw object
+func (d *detailView) setObject(msg objectMsg) {
+	if msg.id != resourceID(d.resource) {
+		return
+	}
+	d.object = msg.object
+	d.objectErr = msg.err
+	d.refreshContent()
+}
+
+// refreshContent re-renders the current tab into the viewport
+func (d *detailView) refreshContent() {
+	offset := d.viewport.YOffset
+	content, matches := d.renderTab()
+	d.matchLines = matches
+	d.viewport.SetContent(content)
+	d.viewport.SetYOffset(offset)
+}
+
+// searchable reports whether the active tab supports search
+func (d detailView) searchable() bool {
+	return d.tab == DetailYAML || d.tab == DetailLogs
+}
+
+// update handles key input; closed is true when the view should be dismissed
+func (d *detailView) update(msg tea.KeyMsg) (closed bool, cmd tea.Cmd) {
+	if d.searching {
+		return false, d.updateSearch(msg)
+	}
+	if d.tab == DetailLogs {
+		if handled, cmd := d.updateLogs(msg); handled {
+			return false, cmd
+		}
+	}
+
+	switch {
+	case msg.String() == "esc" && d.query != "":
+		d.query = ""
+		d.refreshContent()
+	case key.Matches(msg, detailKeys.Close):
+		return true, nil
+	case key.Matches(msg, detailKeys.NextTab):
+		return false, d.setTab(d.tab + 1)
+	case key.Matches(msg, detailKeys.PrevTab):
+		return false, d.setTab(d.tab - 1)
+	case key.Matches(msg, detailKeys.JumpTab):
+		return false, d.setTab(DetailTab(msg.String()[0] - '1'))
+	case key.Matches(msg, detailKeys.Top):
+		d.viewport.GotoTop()
+	case key.Matches(msg, detailKeys.Bottom):
+		d.viewport.GotoBottom()
+	case key.Matches(msg, detailKeys.Search) && d.searchable():
+		d.searching = true
+		d.search.SetValue(d.query)
+		return false, d.search.Focus()
+	case key.Matches(msg, detailKeys.NextMatch):
+		d.jumpToMatch(1)
+	case key.Matches(msg, detailKeys.PrevMatch):
+		d.jumpToMatch(-1)
+	case d.tab == DetailDAG && d.navigateDAG(msg):
+	case key.Matches(msg, detailKeys.ToggleScope) && d.tab == DetailYAML:
+		d.scope = (d.scope + 1) % 3
+		d.refreshContent()
+		d.viewport.GotoTop()
+	case key.Matches(msg, detailKeys.ToggleJSON) && d.tab == DetailYAML:
+		d.asJSON = !d.asJSON
+		d.refreshContent()
+		d.viewport.GotoTop()
+	default:
+		d.viewport, cmd = d.viewport.Update(msg)
+	}
+	return false, cmd
+}
+
+// navigateDAG moves the node selection in the DAG tab; it reports whether
+// the key was handled
+func (d *detailView) navigateDAG(msg tea.KeyMsg) bool {
+	g := layoutDAG(d.resource.DAGNodes)
+	if d.dagSelected == "" {
+		d.dagSelected = g.first()
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		d.dagSelected = g.neighbor(d.dagSelected, -1, 0)
+	case "down", "j":
+		d.dagSelected = g.neighbor(d.dagSelected, 1, 0)
+	case "left", "h":
+		d.dagSelected = g.neighbor(d.dagSelected, 0, -1)
+	case "right", "l":
+		d.dagSelected = g.neighbor(d.dagSelected, 0, 1)
+	default:
+		return false
+	}
+
+	d.refreshContent()
+
+	// Keep the selected node in view
+	_, y := g.origin(d.dagSelected)
+	y += strings.Count(d.renderDAGHeader(g, d.viewport.Width), "\n")
+	if y < d.viewport.YOffset {
+		d.viewport.SetYOffset(y)
+	} else if y+dagBoxHeight > d.viewport.YOffset+d.viewport.Height {
+		d.viewport.SetYOffset(y + dagBoxHeight - d.viewport.Height)
+	}
+	return true
+}
+
+// updateSearch handles key input while the search prompt is focused
+func (d *detailView) updateSearch(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		d.searching = false
+		d.search.Blur()
+		d.query = d.search.Value()
+		d.refreshContent()
+		d.matchIdx = -1
+		d.jumpToMatch(1)
+		return nil
+	case "esc":
+		d.searching = false
+		d.search.Blur()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	d.search, cmd = d.search.Update(msg)
+	return cmd
+}
+
+// jumpToMatch scrolls to the next (dir > 0) or previous search match
+func (d *detailView) jumpToMatch(dir int) {
+	if len(d.matchLines) == 0 {
+		return
+	}
+	d.matchIdx = (d.matchIdx + dir + len(d.matchLines)) % len(d.matchLines)
+	d.viewport.SetYOffset(d.matchLines[d.matchIdx])
+}
+
+// view renders the detail screen
+func (d detailView) view() string {
 	var b strings.Builder
 
-	// Title
-	b.WriteString(detailTitleStyle.Render(fmt.Sprintf("📋 %s: %s", r.Kind, r.Name)))
+	r := d.resource
+	title := fmt.Sprintf("📋 %s: %s", r.Kind, r.Name)
+	if d.gone {
+		title += " (deleted)"
+	}
+	b.WriteString(detailTitleStyle.UnsetMarginBottom().Render(title))
+	b.WriteString("\n")
+	b.WriteString(d.renderTabs())
 	b.WriteString("\n\n")
+	b.WriteString(d.viewport.View())
+	b.WriteString("\n\n")
+
+	if d.searching {
+		b.WriteString(d.search.View())
+	} else {
+		b.WriteString(detailHintStyle.Render(ansi.Truncate(d.footer(), d.viewport.Width, "…")))
+	}
+
+	return detailBoxStyle.Width(d.width - 2).Render(b.String())
+}
+
+// footer returns the key hints for the active tab
+func (d detailView) footer() string {
+	hints := []string{"tab: switch", "↑↓/pgup/pgdn: scroll", "g/G: top/bottom"}
+	if d.tab == DetailDAG {
+		hints[1] = "←↓↑→: select node  pgup/pgdn: scroll"
+	}
+	if d.tab == DetailYAML {
+		hints = append(hints, fmt.Sprintf("v: %s", d.scope), "o: yaml/json")
+	}
+	if d.tab == DetailLogs {
+		hints = append(hints, "[/]: pod", "c: container", "f: follow", "p: previous", "w: wrap")
+	}
+	if d.searchable() {
+		if d.query != "" {
+			hints = append(hints, fmt.Sprintf("n/N: match %d/%d", d.matchIdx+1, len(d.matchLines)))
+		} else {
+			hints = append(hints, "/: search")
+		}
+	}
+	hints = append(hints, "esc: close", fmt.Sprintf("%3.f%%", d.viewport.ScrollPercent()*100))
+	return strings.Join(hints, "  ")
+}
+
+func (d detailView) renderTabs() string {
+	var rendered []string
+	for t := DetailTab(0); t < detailTabCount; t++ {
+		label := fmt.Sprintf("%d:%s", t+1, t)
+		if t == d.tab {
+			rendered = append(rendered, subTabActiveStyle.Render(label))
+		} else {
+			rendered = append(rendered, subTabInactiveStyle.Render(label))
+		}
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+}
+
+// renderTab renders the content of the active sub-tab, along with the
+// line indices matching the search query
+func (d detailView) renderTab() (string, []int) {
+	width := d.viewport.Width
+	switch d.tab {
+	case DetailDAG:
+		return d.renderDAG(width), nil
+	case DetailTimeline:
+		return renderTimeline(d.resource.DAGNodes, width, d.frame), nil
+	case DetailYAML:
+		return d.renderYAML(width)
+	case DetailLogs:
+		return d.renderLogs(width)
+	case DetailPods:
+		return d.renderPods(width), nil
+	case DetailEvents:
+		return d.renderEvents(width), nil
+	default:
+		return renderOverview(d.resource, width), nil
+	}
+}
+
+// renderYAML renders the raw object with syntax highlighting
+func (d detailView) renderYAML(width int) (string, []int) {
+	if d.objectErr != nil {
+		return detailHintStyle.Render(fmt.Sprintf("Failed to fetch object: %v", d.objectErr)), nil
+	}
+	if d.object == nil {
+		return detailHintStyle.Render("Loading..."), nil
+	}
+
+	text, err := formatObject(d.object, d.scope, d.asJSON)
+	if err != nil {
+		return detailHintStyle.Render(err.Error()), nil
+	}
+
+	highlight := highlightYAML
+	if d.asJSON {
+		highlight = highlightJSON
+	}
+	return renderSearchable(text, width, highlight, d.query)
+}
+
+// renderCompactDetail renders a small live summary of a resource for split-pane mode
+func renderCompactDetail(r *types.AsyncResource, width, height int) string {
+	// Border (2) + padding (2)
+	inner := max(width-4, 10)
+
+	var b strings.Builder
+	if r == nil {
+		b.WriteString(detailHintStyle.Render("No resource selected"))
+	} else {
+		b.WriteString(detailTitleStyle.UnsetMarginBottom().Render(fmt.Sprintf("%s: %s", r.Kind, r.Name)))
+		b.WriteString("\n")
+		b.WriteString(renderField("Namespace", r.Namespace))
+		b.WriteString(renderField("Status", formatDetailStatus(r.Status)))
+		if r.StartTime != nil {
+			b.WriteString(renderField("Started", r.StartTime.Format("2006-01-02 15:04:05")))
+		}
+		if r.EndTime != nil {
+			b.WriteString(renderField("Ended", r.EndTime.Format("2006-01-02 15:04:05")))
+		}
+		if r.Duration > 0 {
+			b.WriteString(renderField("Duration", formatDuration(r.Duration)))
+		}
+		if r.Schedule != "" {
+			b.WriteString(renderField("Schedule", r.Schedule))
+		}
+		if r.WarningCount > 0 {
+			b.WriteString(renderField("Warnings", fmt.Sprintf("%d (%s)", r.WarningCount, r.LastWarning)))
+		}
+		if len(r.DAGNodes) > 0 {
+			b.WriteString(renderDAGSummary(r.DAGNodes))
+			b.WriteString("\n")
+		}
+		if r.Message != "" {
+			b.WriteString("\n")
+			b.WriteString(wordWrap(r.Message, inner))
+		}
+	}
+
+	// Fit the content inside the border (2 rows)
+	lines := strings.Split(strings.TrimRight(b.String(), "\n"), "\n")
+	rows := max(height-2, 1)
+	if len(lines) > rows {
+		lines = lines[:rows]
+	}
+	for i, l := range lines {
+		lines[i] = ansi.Truncate(l, inner, "…")
+	}
+
+	return detailBoxStyle.
+		Width(width - 2).
+		Height(rows).
+		Render(strings.Join(lines, "\n"))
+}
+
+// renderOverview renders the basic fields of a resource
+func renderOverview(r types.AsyncResource, width int) string {
+	var b strings.Builder
 
 	// Basic info
 	b.WriteString(renderField("Namespace", r.Namespace))
 	b.WriteString(renderField("Status", formatDetailStatus(r.Status)))
+	if r.ServiceAccount != "" {
+		b.WriteString(renderField("SA", r.ServiceAccount))
+	}
+	if r.ParentName != "" {
+		b.WriteString(renderField("Parent", fmt.Sprintf("%s/%s", r.ParentKind, r.ParentName)))
+	}
 
 	// Timing
 	if r.StartTime != nil {
@@ -55,6 +669,9 @@ func RenderDetail(r types.AsyncResource, width, height int) string {
 	if r.Schedule != "" {
 		b.WriteString(renderField("Schedule", r.Schedule))
 	}
+	if r.Timezone != "" {
+		b.WriteString(renderField("Timezone", r.Timezone))
+	}
 	if r.LastRun != nil {
 		b.WriteString(renderField("Last Run", r.LastRun.Format("2006-01-02 15:04:05")))
 	}
@@ -62,13 +679,40 @@ func RenderDetail(r types.AsyncResource, width, height int) string {
 		b.WriteString(renderField("Next Run", r.NextRun.Format("2006-01-02 15:04:05")))
 	}
 
+	// Kind-specific fields; untitled sections extend the basic info
+	var sections []kinds.Section
+	if k, ok := kinds.Lookup(r.Kind); ok && k.Details != nil {
+		sections = k.Details(r)
+	}
+	for _, s := range sections {
+		if s.Title != "" {
+			continue
+		}
+		for _, f := range s.Fields {
+			b.WriteString(renderField(f.Label, f.Value))
+		}
+	}
+	for _, s := range sections {
+		if s.Title == "" || len(s.Fields) == 0 {
+			continue
+		}
+		b.WriteString("\n")
+		b.WriteString(detailTitleStyle.Render(s.Title))
+		b.WriteString("\n")
+		for _, f := range s.Fields {
+			b.WriteString(renderField(f.Label, f.Value))
+		}
+	}
+
 	// Metrics
-	if r.SuccessCount > 0 || r.FailureCount > 0 {
+	if r.SuccessCount > 0 || r.FailureCount > 0 || r.QueueDepth > 0 {
 		b.WriteString("\n")
 		b.WriteString(detailTitleStyle.Render("📊 Metrics"))
 		b.WriteString("\n")
-		b.WriteString(renderField("Success", fmt.Sprintf("%d", r.SuccessCount)))
-		b.WriteString(renderField("Failures", fmt.Sprintf("%d", r.FailureCount)))
+		if r.SuccessCount > 0 || r.FailureCount > 0 {
+			b.WriteString(renderField("Success", fmt.Sprintf("%d", r.SuccessCount)))
+			b.WriteString(renderField("Failures", fmt.Sprintf("%d", r.FailureCount)))
+		}
 		if r.Retries > 0 {
 			b.WriteString(renderField("Retries", fmt.Sprintf("%d / %d", r.Retries, r.MaxRetries)))
 		}
@@ -80,67 +724,97 @@ func RenderDetail(r types.AsyncResource, width, height int) string {
 		}
 	}
 
+	// Warnings
+	if r.WarningCount > 0 {
+		b.WriteString("\n")
+		b.WriteString(detailTitleStyle.Render(fmt.Sprintf("⚠ Warnings (%d)", r.WarningCount)))
+		b.WriteString("\n")
+		b.WriteString(wordWrap(r.LastWarning, width))
+		b.WriteString("\n")
+	}
+
 	// Message
 	if r.Message != "" {
 		b.WriteString("\n")
 		b.WriteString(detailTitleStyle.Render("💬 Message"))
 		b.WriteString("\n")
-		b.WriteString(wordWrap(r.Message, 50))
+		b.WriteString(wordWrap(r.Message, width))
+		b.WriteString("\n")
 	}
 
-	// DAG Nodes (for Workflow)
+	// DAG summary (full list lives in the DAG tab)
 	if len(r.DAGNodes) > 0 {
 		b.WriteString("\n")
 		b.WriteString(detailTitleStyle.Render("🔄 DAG Progress"))
 		b.WriteString("\n")
+		b.WriteString(renderDAGSummary(r.DAGNodes))
+		b.WriteString("\n")
+	}
 
-		// Count by phase
-		counts := make(map[string]int)
-		for _, node := range r.DAGNodes {
-			counts[node.Phase]++
-		}
+	return b.String()
+}
 
-		// Show summary
-		total := len(r.DAGNodes)
-		succeeded := counts["Succeeded"]
-		running := counts["Running"]
-		failed := counts["Failed"] + counts["Error"]
-		pending := counts["Pending"] + counts["Omitted"]
-
-		summaryStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
-		b.WriteString(summaryStyle.Render(fmt.Sprintf("Total: %d  ", total)))
-		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("34")).Render(fmt.Sprintf("✓%d  ", succeeded)))
-		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("33")).Render(fmt.Sprintf("●%d  ", running)))
-		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(fmt.Sprintf("✗%d  ", failed)))
-		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render(fmt.Sprintf("○%d", pending)))
-		b.WriteString("\n\n")
-
-		// Sort nodes: Running first, then Failed, then others
-		sortedNodes := sortDAGNodes(r.DAGNodes)
-
-		// Show individual nodes (limit to avoid overflow)
-		maxNodes := 20
-		shown := 0
-		for _, node := range sortedNodes {
-			if shown >= maxNodes {
-				remaining := len(sortedNodes) - maxNodes
-				b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render(
-					fmt.Sprintf("... and %d more nodes (✓%d)\n", remaining, counts["Succeeded"]-countShownSucceeded(sortedNodes[:maxNodes]))))
-				break
-			}
-			b.WriteString(formatDAGNode(node))
-			shown++
-		}
+// renderDAGSummary renders per-phase node counts
+func renderDAGSummary(nodes []types.DAGNode) string {
+	counts := make(map[string]int)
+	for _, node := range nodes {
+		counts[node.Phase]++
 	}
 
-	// Footer
+	total := len(nodes)
+	succeeded := counts["Succeeded"]
+	running := counts["Running"]
+	failed := counts["Failed"] + counts["Error"]
+	pending := counts["Pending"] + counts["Omitted"]
+
+	var b strings.Builder
+	summaryStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	b.WriteString(summaryStyle.Render(fmt.Sprintf("Total: %d  ", total)))
+	b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("34")).Render(fmt.Sprintf("✓%d  ", succeeded)))
+	b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("33")).Render(fmt.Sprintf("●%d  ", running)))
+	b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(fmt.Sprintf("✗%d  ", failed)))
+	b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render(fmt.Sprintf("○%d", pending)))
+	return b.String()
+}
+
+// renderDAGHeader renders the summary and the details of the selected node
+func (d detailView) renderDAGHeader(g *dagGraph, width int) string {
+	selected := d.dagSelected
+	if _, ok := g.nodes[selected]; !ok {
+		selected = g.first()
+	}
+
+	var b strings.Builder
+	b.WriteString(renderDAGSummary(d.resource.DAGNodes))
 	b.WriteString("\n\n")
-	b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("Press ESC or Enter to close"))
+	b.WriteString(renderNodeDetails(g.nodes[selected], width))
+	b.WriteString("\n")
+	return b.String()
+}
 
-	content := detailBoxStyle.Render(b.String())
+// renderDAG renders the summary, the selected node and the graph
+func (d detailView) renderDAG(width int) string {
+	r := d.resource
+	if len(r.DAGNodes) == 0 {
+		return detailHintStyle.Render(fmt.Sprintf("No DAG nodes for %s.", r.Kind))
+	}
 
-	// Center the box
-	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, content)
+	g := layoutDAG(r.DAGNodes)
+	selected := d.dagSelected
+	if _, ok := g.nodes[selected]; !ok {
+		selected = g.first()
+	}
+
+	var b strings.Builder
+	b.WriteString(d.renderDAGHeader(g, width))
+
+	// Scroll horizontally to keep the selected node visible
+	xOff := 0
+	if g.width() > width {
+		xOff = min(max(g.center(selected)-width/2, 0), g.width()-width)
+	}
+	b.WriteString(g.draw(selected).render(xOff, width))
+	return b.String()
 }
 
 func renderField(label, value string) string {
@@ -158,6 +832,8 @@ func formatDetailStatus(s types.ResourceStatus) string {
 		return base.Background(lipgloss.Color("52")).Render("✗ Failed")
 	case types.StatusPending:
 		return base.Background(lipgloss.Color("58")).Render("○ Pending")
+	case types.StatusSuspended:
+		return base.Background(lipgloss.Color("54")).Render("⏸ Suspended")
 	default:
 		return base.Background(lipgloss.Color("236")).Render("? Unknown")
 	}
@@ -184,78 +860,83 @@ func wordWrap(text string, width int) string {
 	return result.String()
 }
 
-func formatDAGNode(node types.DAGNode) string {
-	var icon string
-	var style lipgloss.Style
-
-	switch node.Phase {
-	case "Succeeded":
-		icon = "✓"
-		style = lipgloss.NewStyle().Foreground(lipgloss.Color("34"))
-	case "Running":
-		icon = "●"
-		style = lipgloss.NewStyle().Foreground(lipgloss.Color("33"))
-	case "Failed", "Error":
-		icon = "✗"
-		style = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
-	case "Pending":
-		icon = "○"
-		style = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-	case "Omitted":
-		icon = "⊘"
-		style = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
-	default:
-		icon = "?"
-		style = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-	}
+func formatDAGNode(node types.DAGNode, width int) string {
+	style := lipgloss.NewStyle().Foreground(phaseColor(node.Phase))
 
-	name := node.Name
-	if len(name) > 70 {
-		name = name[:67] + "..."
+	info := node.Name
+	if node.Type != "" {
+		info += "  " + detailHintStyle.Render(node.Type)
+	}
+	if node.TemplateName != "" {
+		info += "  " + detailHintStyle.Render("template: "+node.TemplateName)
+	}
+	if node.Attempts > 1 {
+		info += "  " + detailHintStyle.Render(fmt.Sprintf("attempts: %d", node.Attempts))
 	}
 
-	return fmt.Sprintf("%s %s\n", style.Render(icon), name)
+	return ansi.Truncate(fmt.Sprintf("%s %s", style.Render(phaseIcon(node.Phase)), info), width, "…") + "\n"
 }
 
-// sortDAGNodes sorts nodes: Running first, then Failed/Error, then Pending, then Succeeded
-func sortDAGNodes(nodes []types.DAGNode) []types.DAGNode {
-	sorted := make([]types.DAGNode, len(nodes))
-	copy(sorted, nodes)
+// renderNodeDetails renders the execution details of a DAG node
+func renderNodeDetails(node types.DAGNode, width int) string {
+	var b strings.Builder
+	b.WriteString(formatDAGNode(node, width))
 
-	priority := func(phase string) int {
-		switch phase {
-		case "Running":
-			return 0
-		case "Failed", "Error":
-			return 1
-		case "Pending":
-			return 2
-		case "Omitted":
-			return 3
-		case "Succeeded":
-			return 4
-		default:
-			return 5
-		}
+	field := func(label, value string) {
+		b.WriteString(ansi.Truncate(renderField(label, value), width, "…"))
 	}
 
-	for i := 0; i < len(sorted)-1; i++ {
-		for j := i + 1; j < len(sorted); j++ {
-			if priority(sorted[i].Phase) > priority(sorted[j].Phase) {
-				sorted[i], sorted[j] = sorted[j], sorted[i]
-			}
+	if node.PodName != "" {
+		field("Pod", node.PodName)
+	}
+	if node.HostNodeName != "" {
+		field("Host", node.HostNodeName)
+	}
+	if node.StartedAt != nil {
+		timing := node.StartedAt.Format("2006-01-02 15:04:05")
+		if node.Duration > 0 {
+			timing += fmt.Sprintf(" (%s)", formatDuration(node.Duration))
 		}
+		field("Started", timing)
 	}
-
-	return sorted
+	if node.ExitCode != "" {
+		field("Exit Code", node.ExitCode)
+	}
+	if len(node.ResourcesDuration) > 0 {
+		names := make([]string, 0, len(node.ResourcesDuration))
+		for name := range node.ResourcesDuration {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var parts []string
+		for _, name := range names {
+			parts = append(parts, fmt.Sprintf("%s %s", name, formatDuration(time.Duration(node.ResourcesDuration[name])*time.Second)))
+		}
+		field("Resources", strings.Join(parts, ", "))
+	}
+	if len(node.Inputs) > 0 {
+		field("Inputs", formatNodeIO(node.Inputs))
+	}
+	if len(node.Outputs) > 0 {
+		field("Outputs", formatNodeIO(node.Outputs))
+	}
+	if node.Message != "" {
+		b.WriteString(labelStyle.Render("Message:") + " ")
+		b.WriteString(wordWrap(node.Message, max(width-13, 20)))
+		b.WriteString("\n")
+	}
+	return b.String()
 }
 
-func countShownSucceeded(nodes []types.DAGNode) int {
-	count := 0
-	for _, n := range nodes {
-		if n.Phase == "Succeeded" {
-			count++
+// formatNodeIO formats parameters as name=value and artifacts as name@key
+func formatNodeIO(io []types.NodeIO) string {
+	parts := make([]string, 0, len(io))
+	for _, p := range io {
+		if p.Artifact {
+			parts = append(parts, fmt.Sprintf("%s@%s", p.Name, p.Value))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s=%s", p.Name, p.Value))
 		}
 	}
-	return count
+	return strings.Join(parts, ", ")
 }
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+)
+
+// Events tab layout
+const eventTimeW = 20
+
+// eventsMsg carries the Events fetched for the Events tab
+type eventsMsg struct {
+	id     string
+	events []types.EventInfo
+	err    error
+}
+
+// fetchEvents fetches the Events of the resource and its pods
+func (d *detailView) fetchEvents() tea.Cmd {
+	client := d.client
+	r := d.resource
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		events, err := client.ListEvents(ctx, r)
+		return eventsMsg{id: resourceID(r), events: events, err: err}
+	}
+}
+
+// setEvents stores fetched Events
+func (d *detailView) setEvents(msg eventsMsg) {
+	if msg.id != resourceID(d.resource) {
+		return
+	}
+	d.events = msg.events
+	d.eventsErr = msg.err
+	d.refreshContent()
+}
+
+// renderEvents renders the Events as a timeline, oldest first
+func (d detailView) renderEvents(width int) string {
+	if d.eventsErr != nil {
+		return detailHintStyle.Render(fmt.Sprintf("Failed to list events: %v", d.eventsErr))
+	}
+	if d.events == nil {
+		return detailHintStyle.Render("Loading...")
+	}
+	if len(d.events) == 0 {
+		return detailHintStyle.Render(fmt.Sprintf("No events found for %s (events expire after about an hour).", d.resource.Kind))
+	}
+
+	msgW := max(width-eventTimeW-2, 20)
+	var b strings.Builder
+	for _, ev := range d.events {
+		style := lipgloss.NewStyle().Foreground(phaseColor("Succeeded"))
+		icon := "•"
+		if ev.Type == "Warning" {
+			style = warnBadgeStyle.UnsetPadding()
+			icon = "⚠"
+		}
+
+		b.WriteString(axisStyle.Render(padRight(ev.LastSeen.Format("01-02 15:04:05"), eventTimeW-2)))
+		b.WriteString("│ ")
+		b.WriteString(style.Render(icon + " " + ev.Reason))
+		b.WriteString(" " + detailHintStyle.Render(fmt.Sprintf("%s/%s", ev.Kind, ev.Name)))
+		if ev.Count > 1 {
+			b.WriteString(detailHintStyle.Render(fmt.Sprintf(" (x%d since %s)", ev.Count, ev.FirstSeen.Format("15:04:05"))))
+		}
+		b.WriteString("\n")
+		for _, line := range strings.Split(wordWrap(ev.Message, msgW), "\n") {
+			b.WriteString(strings.Repeat(" ", eventTimeW-2))
+			b.WriteString("│   ")
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ginbear/k8s-flowtop/internal/audit"
+)
+
+// maxHistory limits the audit entries shown in the history panel
+const maxHistory = 200
+
+// History panel column widths: TIME, CONTEXT, USER, VERB, OBJECT, RESULT
+var historyColWidths = []int{13, 24, 16, 10, 48, 8}
+
+// recordAudit appends action records to the audit log and the open
+// history panel
+func (m *Model) recordAudit(entries []audit.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	if m.showHistory {
+		m.history = append(append([]audit.Entry(nil), entries...), m.history...)
+	}
+	return m.auditLog.Append(entries...)
+}
+
+// toggleHistory opens the history panel with the latest audit entries, or closes it
+func (m *Model) toggleHistory() {
+	if m.showHistory {
+		m.showHistory = false
+		return
+	}
+	m.history, m.historyErr = m.auditLog.Recent(maxHistory)
+	m.historyOffset = 0
+	m.showHistory = true
+}
+
+// updateHistory handles keys while the history panel is open
+func (m *Model) updateHistory(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k":
+		if m.historyOffset > 0 {
+			m.historyOffset--
+		}
+	case "down", "j":
+		if m.historyOffset < len(m.history)-1 {
+			m.historyOffset++
+		}
+	case "H", "esc":
+		m.showHistory = false
+	case "q", "ctrl+c":
+		return tea.Quit
+	}
+	return nil
+}
+
+// renderHistory renders the audit entries, newest first
+func (m Model) renderHistory(width, maxRows int) string {
+	var b strings.Builder
+	b.WriteString(detailTitleStyle.MarginBottom(0).Render("Action history"))
+	b.WriteString("  ")
+	b.WriteString(detailHintStyle.Render(m.auditLog.Path()))
+	b.WriteString("\n")
+
+	switch {
+	case m.historyErr != nil:
+		b.WriteString(statusErrStyle.Render(fmt.Sprintf("Failed to read audit log: %v", m.historyErr)))
+		b.WriteString("\n")
+		return b.String()
+	case len(m.history) == 0:
+		b.WriteString(detailHintStyle.Render("No actions recorded yet."))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	var header strings.Builder
+	for i, h := range []string{"TIME", "CONTEXT", "USER", "VERB", "OBJECT", "RESULT"} {
+		header.WriteString(headerStyle.Render(padRight(h, historyColWidths[i])))
+	}
+	b.WriteString(clipToWidth(header.String(), width))
+	b.WriteString("\n")
+
+	end := min(m.historyOffset+maxRows-1, len(m.history))
+	for _, e := range m.history[m.historyOffset:end] {
+		b.WriteString(clipToWidth(m.renderHistoryRow(e), width))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderHistoryRow renders a single audit entry
+func (m Model) renderHistoryRow(e audit.Entry) string {
+	object := fmt.Sprintf("%s %s/%s", e.Kind, e.Namespace, e.Name)
+	if e.Created != "" {
+		object += " → " + e.Created
+	}
+
+	cells := []string{
+		padRight(m.formatTime(&e.Time), historyColWidths[0]),
+		padRight(truncate(e.Context, historyColWidths[1]-2), historyColWidths[1]),
+		padRight(truncate(e.User, historyColWidths[2]-2), historyColWidths[2]),
+		padRight(e.Verb, historyColWidths[3]),
+		padRight(truncate(object, historyColWidths[4]-2), historyColWidths[4]),
+	}
+	var row strings.Builder
+	for _, c := range cells {
+		row.WriteString(cellStyle.Render(c))
+	}
+	if e.Result == audit.ResultError {
+		row.WriteString(statusErrStyle.Padding(0, 1).Render(padRight("✗ error", historyColWidths[5])))
+		row.WriteString(detailHintStyle.Render(strings.ReplaceAll(e.Error, "\n", " ")))
+	} else {
+		row.WriteString(statusOKStyle.Padding(0, 1).Render("✓ " + e.Result))
+	}
+	return row.String()
+}
@@ -0,0 +1,365 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ginbear/k8s-flowtop/internal/kinds"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+)
+
+// Namespace event stream limits
+const (
+	maxKubeEvents       = 1000
+	kubeEventsBatchSize = 200
+)
+
+// kubeEventsMsg carries a batch of streamed namespace events
+type kubeEventsMsg struct {
+	events []types.EventInfo
+	done   bool
+	ch     <-chan types.EventInfo
+}
+
+// startEventStream starts streaming namespace events, once
+func (m *Model) startEventStream() tea.Cmd {
+	if m.eventCancel != nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.eventCancel = cancel
+	m.eventCh = m.k8sClient.WatchEvents(ctx)
+	return waitForKubeEvents(m.eventCh)
+}
+
+// waitForKubeEvents waits for the next batch of events from the stream
+func waitForKubeEvents(ch <-chan types.EventInfo) tea.Cmd {
+	return func() tea.Msg {
+		msg := kubeEventsMsg{ch: ch}
+		ev, ok := <-ch
+		if !ok {
+			msg.done = true
+			return msg
+		}
+		msg.events = append(msg.events, ev)
+
+		// Drain whatever else is already buffered
+		for len(msg.events) < kubeEventsBatchSize {
+			select {
+			case ev, ok := <-ch:
+				if !ok {
+					msg.done = true
+					return msg
+				}
+				msg.events = append(msg.events, ev)
+			default:
+				return msg
+			}
+		}
+		return msg
+	}
+}
+
+// mergeKubeEvents adds streamed events, newest first, replacing older
+// copies of the same Event and keeping the selection
+func (m *Model) mergeKubeEvents(msg kubeEventsMsg) tea.Cmd {
+	if msg.ch != m.eventCh {
+		// Stream of a previous context
+		return nil
+	}
+	selected := m.selectedKubeEvent()
+
+	byUID := make(map[string]int, len(m.kubeEvents))
+	for i, ev := range m.kubeEvents {
+		byUID[ev.UID] = i
+	}
+	for _, ev := range msg.events {
+		if i, ok := byUID[ev.UID]; ok {
+			m.kubeEvents[i] = ev
+			continue
+		}
+		byUID[ev.UID] = len(m.kubeEvents)
+		m.kubeEvents = append(m.kubeEvents, ev)
+	}
+	sort.SliceStable(m.kubeEvents, func(i, j int) bool {
+		return m.kubeEvents[i].LastSeen.After(m.kubeEvents[j].LastSeen)
+	})
+	if len(m.kubeEvents) > maxKubeEvents {
+		m.kubeEvents = m.kubeEvents[:maxKubeEvents]
+	}
+
+	m.updateKubeEventRows(selected)
+
+	if msg.done {
+		m.eventCancel = nil
+		m.eventCh = nil
+		return nil
+	}
+	return waitForKubeEvents(msg.ch)
+}
+
+// updateKubeEventRows applies the filter and keeps the selected event under the cursor
+func (m *Model) updateKubeEventRows(selected *types.EventInfo) {
+	var rows []types.EventInfo
+	for _, ev := range m.kubeEvents {
+		if matchKubeEvent(ev, m.eventQuery, m.warningsOnly) {
+			rows = append(rows, ev)
+		}
+	}
+	m.kubeEventRows = rows
+
+	if selected != nil {
+		for i, ev := range rows {
+			if ev.UID == selected.UID {
+				m.eventCursor = i
+				break
+			}
+		}
+	}
+	if m.eventCursor >= len(rows) {
+		m.eventCursor = len(rows) - 1
+	}
+	if m.eventCursor < 0 {
+		m.eventCursor = 0
+	}
+}
+
+// selectedKubeEvent returns the event under the cursor, if any
+func (m Model) selectedKubeEvent() *types.EventInfo {
+	if m.eventCursor >= 0 && m.eventCursor < len(m.kubeEventRows) {
+		ev := m.kubeEventRows[m.eventCursor]
+		return &ev
+	}
+	return nil
+}
+
+// matchKubeEvent reports whether an event passes the filter. The query is a
+// list of words; "kind:" and "reason:" words match those fields only, other
+// words match the reason, kinds, object name or message.
+func matchKubeEvent(ev types.EventInfo, query string, warningsOnly bool) bool {
+	if warningsOnly && ev.Type != "Warning" {
+		return false
+	}
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		contains := func(fields ...string) bool {
+			for _, f := range fields {
+				if strings.Contains(strings.ToLower(f), word) {
+					return true
+				}
+			}
+			return false
+		}
+		switch {
+		case strings.HasPrefix(word, "kind:"):
+			word = strings.TrimPrefix(word, "kind:")
+			if !contains(ev.Kind, string(ev.OwnerKind)) {
+				return false
+			}
+		case strings.HasPrefix(word, "reason:"):
+			word = strings.TrimPrefix(word, "reason:")
+			if !contains(ev.Reason) {
+				return false
+			}
+		default:
+			if !contains(ev.Reason, ev.Kind, string(ev.OwnerKind), ev.Name, ev.OwnerName, ev.Message) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// updateKubeEvents handles keys of the namespace event view; it reports
+// whether the key was handled
+func (m *Model) updateKubeEvents(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if m.filteringEvents {
+		switch msg.String() {
+		case "enter":
+			m.filteringEvents = false
+			m.eventFilter.Blur()
+		case "esc":
+			m.filteringEvents = false
+			m.eventFilter.Blur()
+			m.eventFilter.SetValue("")
+		default:
+			var cmd tea.Cmd
+			m.eventFilter, cmd = m.eventFilter.Update(msg)
+			m.eventQuery = m.eventFilter.Value()
+			m.updateKubeEventRows(m.selectedKubeEvent())
+			return true, cmd
+		}
+		m.eventQuery = m.eventFilter.Value()
+		m.updateKubeEventRows(m.selectedKubeEvent())
+		return true, nil
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.eventCursor > 0 {
+			m.eventCursor--
+		}
+	case key.Matches(msg, m.keys.Down):
+		if m.eventCursor < len(m.kubeEventRows)-1 {
+			m.eventCursor++
+		}
+	case key.Matches(msg, m.keys.Enter):
+		if ev := m.selectedKubeEvent(); ev != nil {
+			m.jumpToResource(ev.OwnerKind, ev.Namespace, ev.OwnerName)
+		}
+	case key.Matches(msg, m.keys.Filter):
+		m.filteringEvents = true
+		m.eventFilter.SetValue(m.eventQuery)
+		m.eventFilter.CursorEnd()
+		return true, m.eventFilter.Focus()
+	case key.Matches(msg, m.keys.WarnOnly):
+		m.warningsOnly = !m.warningsOnly
+		m.updateKubeEventRows(m.selectedKubeEvent())
+	case msg.String() == "esc":
+		m.eventQuery = ""
+		m.eventFilter.SetValue("")
+		m.updateKubeEventRows(m.selectedKubeEvent())
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// jumpToResource switches to the tab listing a resource and selects its row
+func (m *Model) jumpToResource(kind types.ResourceKind, namespace, name string) {
+	var target *types.AsyncResource
+	for i, r := range m.resources {
+		if r.Kind == kind && r.Namespace == namespace && r.Name == name {
+			target = &m.resources[i]
+			break
+		}
+	}
+	if target == nil {
+		return
+	}
+
+	m.viewMode = types.ViewAll
+	if k, ok := kinds.Lookup(kind); ok {
+		if k.View == types.ViewQueues {
+			m.showQueues = true
+			m.queuesOffset = 0
+			return
+		}
+		m.viewMode = k.View
+	}
+	if target.ParentName != "" {
+		m.expanded[treeKey(target.Namespace, target.ParentName)] = true
+	}
+	m.updateFiltered()
+
+	id := resourceID(*target)
+	for i, r := range m.filteredCache {
+		if resourceID(r) == id {
+			m.cursor = i
+			break
+		}
+	}
+}
+
+// kubeEventResource returns the workload row an event belongs to, if listed
+func (m Model) kubeEventResource(ev *types.EventInfo) *types.AsyncResource {
+	if ev == nil {
+		return nil
+	}
+	for i, r := range m.resources {
+		if r.Kind == ev.OwnerKind && r.Namespace == ev.Namespace && r.Name == ev.OwnerName {
+			return &m.resources[i]
+		}
+	}
+	return nil
+}
+
+// renderKubeEventTable renders the namespace event stream
+func (m Model) renderKubeEventTable(width, maxRows int) string {
+	var b strings.Builder
+
+	b.WriteString(clipToWidth(m.renderHeader(), width))
+	b.WriteString("\n")
+
+	// Filter line
+	if m.filteringEvents || m.eventQuery != "" || m.warningsOnly {
+		filter := m.eventFilter.View()
+		if !m.filteringEvents {
+			filter = "/" + m.eventQuery
+		}
+		if m.warningsOnly {
+			filter += detailHintStyle.Render("  [warnings only]")
+		}
+		b.WriteString(clipToWidth(filter, width))
+		b.WriteString("\n")
+		maxRows--
+	}
+
+	if forbidden := m.renderForbidden(); forbidden != "" {
+		b.WriteString(clipToWidth(forbidden, width))
+		b.WriteString("\n")
+		maxRows--
+	}
+
+	if len(m.kubeEventRows) == 0 {
+		msg := "Waiting for events..."
+		if len(m.kubeEvents) > 0 {
+			msg = "No events match the filter."
+		}
+		b.WriteString(detailHintStyle.Render(msg))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	startIdx := 0
+	if m.eventCursor >= maxRows {
+		startIdx = m.eventCursor - maxRows + 1
+	}
+	endIdx := min(startIdx+maxRows, len(m.kubeEventRows))
+
+	for i := startIdx; i < endIdx; i++ {
+		b.WriteString(clipToWidth(m.renderKubeEventRow(m.kubeEventRows[i], i == m.eventCursor), width))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderKubeEventRow renders a single event row
+func (m Model) renderKubeEventRow(ev types.EventInfo, isSelected bool) string {
+	colWidths, _ := m.getColumnConfig()
+
+	owner := "-"
+	if ev.OwnerName != "" && (string(ev.OwnerKind) != ev.Kind || ev.OwnerName != ev.Name) {
+		owner = string(ev.OwnerKind) + "/" + ev.OwnerName
+	}
+	message := strings.ReplaceAll(ev.Message, "\n", " ")
+	if ev.Count > 1 {
+		message = fmt.Sprintf("(x%d) %s", ev.Count, message)
+	}
+
+	cells := []string{
+		padRight(m.formatTime(&ev.LastSeen), colWidths[0]),
+		padRight(ev.Type, colWidths[1]),
+		padRight(truncate(ev.Reason, colWidths[2]-2), colWidths[2]),
+		padRight(truncate(ev.Kind+"/"+ev.Name, colWidths[3]-2), colWidths[3]),
+		padRight(truncate(owner, colWidths[4]-2), colWidths[4]),
+		padRight(truncate(ev.Namespace, colWidths[5]-2), colWidths[5]),
+		padRight(truncateMsg(message, colWidths[6]-2), colWidths[6]),
+	}
+
+	var result strings.Builder
+	for i, cell := range cells {
+		switch {
+		case isSelected:
+			result.WriteString(selectedRowStyle.Render(cell))
+		case i == 1 && ev.Type == "Warning":
+			result.WriteString(warnBadgeStyle.Render(cell))
+		default:
+			result.WriteString(cellStyle.Render(cell))
+		}
+	}
+	return result.String()
+}
@@ -0,0 +1,361 @@
+package tui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ginbear/k8s-flowtop/internal/k8s"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+)
+
+// Log buffer limits
+const (
+	maxLogLines  = 5000
+	logTailLines = 1000
+	logBatchSize = 500
+)
+
+// podsMsg carries the pods fetched for the Pods and Logs tabs
+type podsMsg struct {
+	id   string
+	pods []types.PodInfo
+	err  error
+}
+
+// logChunk is a single line (or error) read from a log stream
+type logChunk struct {
+	line string
+	err  error
+}
+
+// logLinesMsg carries a batch of log lines from the active stream
+type logLinesMsg struct {
+	gen   int
+	lines []string
+	err   error
+	done  bool
+	ch    <-chan logChunk
+}
+
+// fetchPods fetches the pods behind the resource
+func (d *detailView) fetchPods() tea.Cmd {
+	client := d.client
+	r := d.resource
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		pods, err := client.ListPods(ctx, r)
+		return podsMsg{id: resourceID(r), pods: pods, err: err}
+	}
+}
+
+// setPods stores fetched pods and keeps the log selection valid
+func (d *detailView) setPods(msg podsMsg) tea.Cmd {
+	if msg.id != resourceID(d.resource) {
+		return nil
+	}
+	d.pods = msg.pods
+	d.podsErr = msg.err
+
+	if d.selectedPod() == nil {
+		d.logPod = ""
+		// Prefer the pod of the node highlighted in the DAG tab
+		for _, n := range d.resource.DAGNodes {
+			if n.ID == d.dagSelected && n.PodName != "" {
+				d.logPod = n.PodName
+			}
+		}
+		if d.selectedPod() == nil && len(d.pods) > 0 {
+			d.logPod = d.pods[0].Name
+		}
+	}
+	if pod := d.selectedPod(); pod != nil && !containsString(pod.Containers, d.logContainer) {
+		d.logContainer = pod.Default
+	}
+
+	d.refreshContent()
+	if d.tab == DetailLogs {
+		return d.ensureLogs()
+	}
+	return nil
+}
+
+// selectedPod returns the pod whose logs are shown
+func (d *detailView) selectedPod() *types.PodInfo {
+	for i := range d.pods {
+		if d.pods[i].Name == d.logPod {
+			return &d.pods[i]
+		}
+	}
+	return nil
+}
+
+// logStreamKey identifies the stream matching the current log options
+func (d *detailView) logStreamKey() string {
+	return fmt.Sprintf("%s/%s/%t/%t", d.logPod, d.logContainer, d.logFollow, d.logPrevious)
+}
+
+// ensureLogs (re)starts the log stream if the selection changed
+func (d *detailView) ensureLogs() tea.Cmd {
+	if d.selectedPod() == nil || d.logStreamKey() == d.logKey {
+		return nil
+	}
+	return d.startLogs()
+}
+
+// startLogs cancels the active stream and opens a new one
+func (d *detailView) startLogs() tea.Cmd {
+	d.stopLogs()
+
+	pod := d.selectedPod()
+	if pod == nil {
+		return nil
+	}
+
+	d.logGen++
+	d.logKey = d.logStreamKey()
+	d.logLines = nil
+	d.logErr = nil
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.logCancel = cancel
+
+	client := d.client
+	gen := d.logGen
+	namespace, name := pod.Namespace, pod.Name
+	opts := k8s.LogOptions{
+		Container: d.logContainer,
+		Follow:    d.logFollow,
+		Previous:  d.logPrevious,
+		TailLines: logTailLines,
+	}
+	return func() tea.Msg {
+		stream, err := client.StreamLogs(ctx, namespace, name, opts)
+		if err != nil {
+			return logLinesMsg{gen: gen, err: err, done: true}
+		}
+
+		ch := make(chan logChunk, 256)
+		go func() {
+			defer close(ch)
+			defer stream.Close()
+
+			scanner := bufio.NewScanner(stream)
+			scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+			for scanner.Scan() {
+				select {
+				case ch <- logChunk{line: scanner.Text()}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err := scanner.Err(); err != nil && ctx.Err() == nil {
+				ch <- logChunk{err: err}
+			}
+		}()
+		return waitForLogs(gen, ch)()
+	}
+}
+
+// stopLogs cancels the active log stream
+func (d *detailView) stopLogs() {
+	if d.logCancel != nil {
+		d.logCancel()
+		d.logCancel = nil
+	}
+	d.logKey = ""
+}
+
+// waitForLogs waits for the next batch of lines from a log stream
+func waitForLogs(gen int, ch <-chan logChunk) tea.Cmd {
+	return func() tea.Msg {
+		msg := logLinesMsg{gen: gen, ch: ch}
+		add := func(c logChunk) {
+			if c.err != nil {
+				msg.err = c.err
+			} else {
+				msg.lines = append(msg.lines, c.line)
+			}
+		}
+
+		chunk, ok := <-ch
+		if !ok {
+			msg.done = true
+			return msg
+		}
+		add(chunk)
+
+		// Drain whatever else is already buffered
+		for len(msg.lines) < logBatchSize {
+			select {
+			case chunk, ok := <-ch:
+				if !ok {
+					msg.done = true
+					return msg
+				}
+				add(chunk)
+			default:
+				return msg
+			}
+		}
+		return msg
+	}
+}
+
+// appendLogs adds streamed lines and waits for more
+func (d *detailView) appendLogs(msg logLinesMsg) tea.Cmd {
+	if msg.gen != d.logGen {
+		return nil
+	}
+
+	atBottom := d.viewport.AtBottom()
+	d.logLines = append(d.logLines, msg.lines...)
+	if len(d.logLines) > maxLogLines {
+		d.logLines = d.logLines[len(d.logLines)-maxLogLines:]
+	}
+	if msg.err != nil {
+		d.logErr = msg.err
+	}
+
+	if d.tab == DetailLogs {
+		d.refreshContent()
+		if d.logFollow && atBottom {
+			d.viewport.GotoBottom()
+		}
+	}
+
+	if msg.done {
+		d.logCancel = nil
+		return nil
+	}
+	return waitForLogs(msg.gen, msg.ch)
+}
+
+// updateLogs handles Logs tab keys; it reports whether the key was handled
+func (d *detailView) updateLogs(msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.String() {
+	case "]", "[":
+		if len(d.pods) == 0 {
+			return true, nil
+		}
+		i := 0
+		for j, p := range d.pods {
+			if p.Name == d.logPod {
+				i = j
+			}
+		}
+		if msg.String() == "]" {
+			i = (i + 1) % len(d.pods)
+		} else {
+			i = (i - 1 + len(d.pods)) % len(d.pods)
+		}
+		d.logPod = d.pods[i].Name
+		d.logContainer = d.pods[i].Default
+	case "c":
+		pod := d.selectedPod()
+		if pod == nil || len(pod.Containers) == 0 {
+			return true, nil
+		}
+		i := 0
+		for j, c := range pod.Containers {
+			if c == d.logContainer {
+				i = j
+			}
+		}
+		d.logContainer = pod.Containers[(i+1)%len(pod.Containers)]
+	case "p":
+		d.logPrevious = !d.logPrevious
+	case "f":
+		d.logFollow = !d.logFollow
+	case "w":
+		d.logWrap = !d.logWrap
+		d.refreshContent()
+		return true, nil
+	default:
+		return false, nil
+	}
+
+	cmd := d.ensureLogs()
+	d.refreshContent()
+	d.viewport.GotoBottom()
+	return true, cmd
+}
+
+// renderLogs renders the log selection header and the log lines
+func (d detailView) renderLogs(width int) (string, []int) {
+	if d.podsErr != nil {
+		return detailHintStyle.Render(fmt.Sprintf("Failed to list pods: %v", d.podsErr)), nil
+	}
+	if d.pods == nil {
+		return detailHintStyle.Render("Loading..."), nil
+	}
+	pod := d.selectedPod()
+	if pod == nil {
+		return detailHintStyle.Render(fmt.Sprintf("No pods found for %s.", d.resource.Kind)), nil
+	}
+
+	podIdx := 0
+	for i, p := range d.pods {
+		if p.Name == pod.Name {
+			podIdx = i
+		}
+	}
+	podLabel := pod.Name
+	if pod.NodeName != "" {
+		podLabel += " (" + pod.NodeName + ")"
+	}
+
+	var header strings.Builder
+	header.WriteString(renderField("Pod", fmt.Sprintf("%s [%d/%d]", podLabel, podIdx+1, len(d.pods))))
+	header.WriteString(renderField("Container", fmt.Sprintf("%s  %s", d.logContainer,
+		detailHintStyle.Render(fmt.Sprintf("follow: %s  previous: %s  wrap: %s", onOff(d.logFollow), onOff(d.logPrevious), onOff(d.logWrap))))))
+	header.WriteString("\n")
+	headerLines := strings.Count(header.String(), "\n")
+
+	if d.logErr != nil {
+		header.WriteString(detailHintStyle.Render(fmt.Sprintf("Error: %v", d.logErr)))
+		header.WriteString("\n")
+		headerLines++
+	}
+	if len(d.logLines) == 0 {
+		header.WriteString(detailHintStyle.Render("No log lines yet."))
+		return header.String(), nil
+	}
+
+	lines := d.logLines
+	if d.logWrap {
+		var wrapped []string
+		for _, l := range lines {
+			wrapped = append(wrapped, strings.Split(ansi.Hardwrap(l, width, true), "\n")...)
+		}
+		lines = wrapped
+	}
+
+	body, matches := renderSearchable(strings.Join(lines, "\n"), width, func(s string) string { return s }, d.query)
+	for i := range matches {
+		matches[i] += headerLines
+	}
+	return header.String() + body, matches
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
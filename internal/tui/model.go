@@ -9,9 +9,14 @@ import (
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ginbear/k8s-flowtop/internal/audit"
+	"github.com/ginbear/k8s-flowtop/internal/config"
 	"github.com/ginbear/k8s-flowtop/internal/k8s"
+	"github.com/ginbear/k8s-flowtop/internal/kinds"
 	"github.com/ginbear/k8s-flowtop/internal/types"
 	"github.com/robfig/cron/v3"
 )
@@ -32,6 +37,7 @@ var (
 	succeededBg = lipgloss.Color("22")  // dark green
 	failedBg    = lipgloss.Color("52")  // dark red
 	pendingBg   = lipgloss.Color("58")  // dark yellow/olive
+	suspendedBg = lipgloss.Color("54")  // dark purple
 	unknownBg   = lipgloss.Color("236") // dark gray
 
 	headerStyle = lipgloss.NewStyle().
@@ -60,22 +66,40 @@ var (
 				Background(lipgloss.Color("236")).
 				Padding(0, 2)
 
+	warnBadgeStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("214")).
+			Bold(true).
+			Padding(0, 1)
+
+	readOnlyBadgeStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("231")).
+				Background(lipgloss.Color("160")).
+				Bold(true).
+				Padding(0, 1)
+
 	separatorStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("240"))
 )
 
 // Column definitions per view mode
 // All view: simple overview
-var colWidthsAll = []int{14, 15, 45, 12, 20, 10, 30}
-var colHeadersAll = []string{"KIND", "NAMESPACE", "NAME", "STATUS", "SA", "DURATION", "MESSAGE"}
+var colWidthsAll = []int{14, 15, 45, 12, 6, 20, 10, 30}
+var colHeadersAll = []string{"KIND", "NAMESPACE", "NAME", "STATUS", "WARN", "SA", "DURATION", "MESSAGE"}
 
 // Jobs/Workflows view: schedule-focused
-var colWidthsJobs = []int{14, 15, 38, 12, 20, 10, 5, 5, 5, 5, 5, 12, 13, 13, 20}
-var colHeadersJobs = []string{"KIND", "NAMESPACE", "NAME", "STATUS", "SA", "DURATION", "MIN", "HRS", "DAY", "MON", "DOW", "TZ", "LAST", "NEXT", "MESSAGE"}
+var colWidthsJobs = []int{14, 15, 38, 12, 6, 20, 10, 5, 5, 5, 5, 5, 12, 13, 13, 20}
+var colHeadersJobs = []string{"KIND", "NAMESPACE", "NAME", "STATUS", "WARN", "SA", "DURATION", "MIN", "HRS", "DAY", "MON", "DOW", "TZ", "LAST", "NEXT", "MESSAGE"}
+
+// Other views: common columns, followed by those of the listed kinds
+var colWidthsKinds = []int{13, 15, 28, 10, 6, 20}
+var colHeadersKinds = []string{"KIND", "NAMESPACE", "NAME", "STATUS", "WARN", "SA"}
 
-// Events view: event-focused
-var colWidthsEvents = []int{13, 15, 28, 10, 20, 20, 35, 35}
-var colHeadersEvents = []string{"KIND", "NAMESPACE", "NAME", "STATUS", "SA", "EVENT_SOURCE", "EVENT_NAME", "TRIGGER"}
+// Other views end with the run history of collapsed groups
+const colWidthRuns = 24
+
+// K8s Events view: namespace event stream
+var colWidthsKubeEvents = []int{13, 9, 22, 36, 28, 15, 50}
+var colHeadersKubeEvents = []string{"LAST", "TYPE", "REASON", "OBJECT", "OWNER", "NAMESPACE", "MESSAGE"}
 
 // SortMode represents the current sort mode
 type SortMode int
@@ -108,8 +132,26 @@ type KeyMap struct {
 	Jobs       key.Binding
 	Flows      key.Binding
 	Events     key.Binding
+	KubeEvents key.Binding
+	Filter     key.Binding
+	WarnOnly   key.Binding
+	Suspend    key.Binding
+	RunNow     key.Binding
+	Retry      key.Binding
+	Resubmit   key.Binding
+	Stop       key.Binding
+	Terminate  key.Binding
+	Delete     key.Binding
+	Cleanup    key.Binding
+	History    key.Binding
+	Queues     key.Binding
+	Context    key.Binding
 	ToggleJST  key.Binding
 	ToggleSort key.Binding
+	SplitPane  key.Binding
+	Expand     key.Binding
+	Collapse   key.Binding
+	ToggleTree key.Binding
 }
 
 var keys = KeyMap{
@@ -161,6 +203,62 @@ var keys = KeyMap{
 		key.WithKeys("4"),
 		key.WithHelp("4", "events"),
 	),
+	KubeEvents: key.NewBinding(
+		key.WithKeys("5"),
+		key.WithHelp("5", "k8s events"),
+	),
+	Filter: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "filter events"),
+	),
+	WarnOnly: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "warnings only"),
+	),
+	Suspend: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "suspend/resume"),
+	),
+	RunNow: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "run now"),
+	),
+	Retry: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "retry/re-run"),
+	),
+	Resubmit: key.NewBinding(
+		key.WithKeys("U"),
+		key.WithHelp("U", "resubmit workflow"),
+	),
+	Stop: key.NewBinding(
+		key.WithKeys("X"),
+		key.WithHelp("X", "stop workflow"),
+	),
+	Terminate: key.NewBinding(
+		key.WithKeys("K"),
+		key.WithHelp("K", "terminate workflow"),
+	),
+	Delete: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "delete"),
+	),
+	Cleanup: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "clean up finished"),
+	),
+	History: key.NewBinding(
+		key.WithKeys("H"),
+		key.WithHelp("H", "action history"),
+	),
+	Queues: key.NewBinding(
+		key.WithKeys("Q"),
+		key.WithHelp("Q", "queue overview"),
+	),
+	Context: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "switch context"),
+	),
 	ToggleJST: key.NewBinding(
 		key.WithKeys("J"),
 		key.WithHelp("J", "toggle JST/UTC"),
@@ -169,6 +267,22 @@ var keys = KeyMap{
 		key.WithKeys("s"),
 		key.WithHelp("s", "sort by next run"),
 	),
+	SplitPane: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "split pane"),
+	),
+	Expand: key.NewBinding(
+		key.WithKeys("right", "l"),
+		key.WithHelp("→/l", "expand"),
+	),
+	Collapse: key.NewBinding(
+		key.WithKeys("left", "h"),
+		key.WithHelp("←/h", "collapse"),
+	),
+	ToggleTree: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "toggle tree"),
+	),
 }
 
 func (k KeyMap) ShortHelp() []key.Binding {
@@ -178,56 +292,119 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Tab, k.ShiftTab},
-		{k.All, k.Jobs, k.Flows, k.Events},
-		{k.Refresh, k.Enter, k.Quit, k.Help},
+		{k.All, k.Jobs, k.Flows, k.Events, k.KubeEvents},
+		{k.Expand, k.Collapse, k.ToggleTree, k.SplitPane},
+		{k.Filter, k.WarnOnly},
+		{k.Suspend, k.RunNow},
+		{k.Retry, k.Resubmit, k.Stop, k.Terminate},
+		{k.Delete, k.Cleanup, k.History, k.Queues},
+		{k.Context, k.Refresh, k.Enter, k.Quit, k.Help},
 	}
 }
 
 // Model is the main TUI model
 type Model struct {
-	k8sClient        *k8s.Client
-	resources        []types.AsyncResource
-	filteredCache    []types.AsyncResource
-	treePrefixes     []string // tree prefix for each item in filteredCache
-	cursor           int
-	viewMode         types.ViewMode
-	sortMode         SortMode
-	help             help.Model
-	keys             KeyMap
-	showHelp         bool
-	showDetail       bool
-	selectedResource *types.AsyncResource
-	err              error
-	width            int
-	height           int
-	lastUpdate       time.Time
-	useJST           bool
-	jstLocation      *time.Location
+	k8sClient     *k8s.Client
+	cfg           config.Config
+	resources     []types.AsyncResource
+	filteredCache []types.AsyncResource
+	treePrefixes  []string        // tree prefix for each item in filteredCache
+	treeSummaries []string        // run-history strip for collapsed parents
+	expanded      map[string]bool // key: "namespace/parentName"
+	cursor        int
+	viewMode      types.ViewMode
+	sortMode      SortMode
+	help          help.Model
+	keys          KeyMap
+	showHelp      bool
+	showDetail    bool
+	detail        detailView
+	splitPane     bool
+	err           error
+	width         int
+	height        int
+	lastUpdate    time.Time
+	useJST        bool
+	jstLocation   *time.Location
+
+	// K8s Events view
+	kubeEvents      []types.EventInfo // newest first
+	kubeEventRows   []types.EventInfo // kubeEvents after filtering
+	eventCursor     int
+	eventFilter     textinput.Model
+	filteringEvents bool
+	eventQuery      string
+	warningsOnly    bool
+	eventCancel     context.CancelFunc
+	eventCh         <-chan types.EventInfo
+
+	// Actions
+	confirmation *confirmDialog
+	status       string
+	statusErr    error
+	statusAt     time.Time
+
+	// Audit log and history panel
+	auditLog      *audit.Log
+	showHistory   bool
+	history       []audit.Entry // newest first
+	historyErr    error
+	historyOffset int
+
+	// Kueue queue overview
+	showQueues   bool
+	queuesOffset int
+
+	// RBAC
+	access        types.Access
+	accessChecked map[string]bool // namespaces whose write access was checked
+	forbidden     map[types.ResourceKind]bool
+	listErrors    map[types.ResourceKind]error // kinds whose last listing failed
+
+	// Context switching
+	contextPicker *contextPicker
 }
 
 // Messages
 type tickMsg time.Time
-type resourcesMsg []types.AsyncResource
+type resourcesMsg struct {
+	client    *k8s.Client // client the resources were read through
+	resources []types.AsyncResource
+	forbidden []types.ResourceKind         // kinds the user may not list
+	failed    map[types.ResourceKind]error // kinds whose listing failed otherwise
+}
 type errMsg struct{ error }
 
 // NewModel creates a new TUI model
-func NewModel(client *k8s.Client) Model {
+func NewModel(client *k8s.Client, cfg config.Config) Model {
 	jst, _ := time.LoadLocation("Asia/Tokyo")
+	filter := textinput.New()
+	filter.Prompt = "/"
+	filter.Placeholder = "filter (kind:Pod reason:BackOff ...)"
+
 	return Model{
-		k8sClient:   client,
-		viewMode:    types.ViewAll,
-		help:        help.New(),
-		keys:        keys,
-		showHelp:    false,
-		cursor:      0,
-		useJST:      false,
-		jstLocation: jst,
+		k8sClient:     client,
+		cfg:           cfg,
+		auditLog:      audit.New(cfg.AuditLog),
+		access:        make(types.Access),
+		accessChecked: make(map[string]bool),
+		viewMode:      types.ViewAll,
+		help:          help.New(),
+		keys:          keys,
+		showHelp:      false,
+		cursor:        0,
+		useJST:        false,
+		jstLocation:   jst,
+		expanded:      make(map[string]bool),
+		eventFilter:   filter,
 	}
 }
 
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
+		m.discoverAPIs(),
 		m.fetchResources(),
+		m.checkReadAccess(),
 		m.tickCmd(),
 	)
 }
@@ -237,14 +414,39 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		// Handle detail view escape
+		// Detail view handles its own keys
 		if m.showDetail {
-			switch msg.String() {
-			case "esc", "enter", "q":
+			closed, cmd := m.detail.update(msg)
+			if closed {
+				m.detail.stopLogs()
 				m.showDetail = false
-				m.selectedResource = nil
-				return m, nil
 			}
+			return m, cmd
+		}
+
+		if m.confirmation != nil {
+			return m, m.updateConfirm(msg)
+		}
+
+		if m.contextPicker != nil {
+			return m, m.updateContextPicker(msg)
+		}
+
+		if m.showHistory {
+			return m, m.updateHistory(msg)
+		}
+
+		if m.showQueues {
+			return m, m.updateQueues(msg)
+		}
+
+		if m.viewMode == types.ViewKubeEvents {
+			if handled, cmd := m.updateKubeEvents(msg); handled {
+				return m, cmd
+			}
+		}
+
+		if m.refuseWrite(msg) || m.refuseDenied(msg) {
 			return m, nil
 		}
 
@@ -274,45 +476,31 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.Enter):
 			// Show detail view
 			if m.cursor >= 0 && m.cursor < len(m.filteredCache) {
-				r := m.filteredCache[m.cursor]
-				m.selectedResource = &r
+				m.detail = newDetailView(m.k8sClient, m.filteredCache[m.cursor], m.width, m.height)
 				m.showDetail = true
 			}
 			return m, nil
 
 		case key.Matches(msg, m.keys.Tab):
-			m.viewMode = (m.viewMode + 1) % 4
-			m.updateFiltered()
-			return m, nil
+			return m, m.setViewMode((m.viewMode + 1) % 5)
 
 		case key.Matches(msg, m.keys.ShiftTab):
-			if m.viewMode == 0 {
-				m.viewMode = 3
-			} else {
-				m.viewMode--
-			}
-			m.updateFiltered()
-			return m, nil
+			return m, m.setViewMode((m.viewMode + 4) % 5)
 
 		case key.Matches(msg, m.keys.All):
-			m.viewMode = types.ViewAll
-			m.updateFiltered()
-			return m, nil
+			return m, m.setViewMode(types.ViewAll)
 
 		case key.Matches(msg, m.keys.Jobs):
-			m.viewMode = types.ViewJobs
-			m.updateFiltered()
-			return m, nil
+			return m, m.setViewMode(types.ViewJobs)
 
 		case key.Matches(msg, m.keys.Flows):
-			m.viewMode = types.ViewWorkflows
-			m.updateFiltered()
-			return m, nil
+			return m, m.setViewMode(types.ViewWorkflows)
 
 		case key.Matches(msg, m.keys.Events):
-			m.viewMode = types.ViewEvents
-			m.updateFiltered()
-			return m, nil
+			return m, m.setViewMode(types.ViewEvents)
+
+		case key.Matches(msg, m.keys.KubeEvents):
+			return m, m.setViewMode(types.ViewKubeEvents)
 
 		case key.Matches(msg, m.keys.ToggleJST):
 			m.useJST = !m.useJST
@@ -326,21 +514,167 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.updateFiltered()
 			return m, nil
+
+		case key.Matches(msg, m.keys.Suspend):
+			m.toggleSuspend()
+			return m, nil
+
+		case key.Matches(msg, m.keys.RunNow):
+			return m, m.triggerRun()
+
+		case key.Matches(msg, m.keys.Retry):
+			if r := m.selectedResource(); r != nil && r.Kind == types.KindJob {
+				m.rerunJob()
+				return m, nil
+			}
+			return m, m.workflowAction(actionRetry)
+
+		case key.Matches(msg, m.keys.Resubmit):
+			return m, m.workflowAction(actionResubmit)
+
+		case key.Matches(msg, m.keys.Stop):
+			return m, m.workflowAction(actionStop)
+
+		case key.Matches(msg, m.keys.Terminate):
+			return m, m.workflowAction(actionTerminate)
+
+		case key.Matches(msg, m.keys.Delete):
+			return m, m.deleteResource()
+
+		case key.Matches(msg, m.keys.Cleanup):
+			return m, m.cleanup()
+
+		case key.Matches(msg, m.keys.Queues):
+			m.showQueues = true
+			m.queuesOffset = 0
+			return m, nil
+
+		case key.Matches(msg, m.keys.History):
+			m.toggleHistory()
+			return m, nil
+
+		case key.Matches(msg, m.keys.Context):
+			m.openContextPicker()
+			return m, nil
+
+		case key.Matches(msg, m.keys.SplitPane):
+			m.splitPane = !m.splitPane
+			return m, nil
+
+		case key.Matches(msg, m.keys.Expand):
+			m.setExpanded(true)
+			return m, nil
+
+		case key.Matches(msg, m.keys.Collapse):
+			m.setExpanded(false)
+			return m, nil
+
+		case key.Matches(msg, m.keys.ToggleTree):
+			if m.cursor >= 0 && m.cursor < len(m.filteredCache) {
+				r := m.filteredCache[m.cursor]
+				if r.ParentName == "" {
+					m.setExpanded(!m.expanded[treeKey(r.Namespace, r.Name)])
+				}
+			}
+			return m, nil
 		}
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		m.help.Width = msg.Width
+		m.detail.setSize(msg.Width, msg.Height)
 		return m, nil
 
 	case tickMsg:
 		cmds = append(cmds, m.fetchResources(), m.tickCmd())
 
 	case resourcesMsg:
-		m.resources = msg
+		if msg.client != m.k8sClient {
+			// Read before a context switch
+			break
+		}
+		m.resources = msg.resources
+		m.forbidden = make(map[types.ResourceKind]bool)
+		for _, kind := range msg.forbidden {
+			m.forbidden[kind] = true
+		}
+		m.listErrors = msg.failed
 		m.lastUpdate = time.Now()
 		m.updateFiltered()
+		cmds = append(cmds, m.checkWriteAccess())
+		if m.showDetail {
+			cmds = append(cmds, m.refreshDetail())
+		}
+
+	case objectMsg:
+		if m.showDetail {
+			m.detail.setObject(msg)
+		}
+
+	case podsMsg:
+		if m.showDetail {
+			cmds = append(cmds, m.detail.setPods(msg))
+		}
+
+	case eventsMsg:
+		if m.showDetail {
+			m.detail.setEvents(msg)
+		}
+
+	case paramsMsg:
+		cmds = append(cmds, m.confirmSubmit(msg))
+
+	case accessMsg:
+		m.mergeAccess(msg)
+
+	case apisMsg:
+		if msg.client != m.k8sClient {
+			break
+		}
+		// Read again at the served versions, without uninstalled kinds
+		m.k8sClient.SetAPIs(msg.apis)
+		cmds = append(cmds, m.fetchResources())
+
+	case clientMsg:
+		if msg.err != nil {
+			m.setStatus("", fmt.Errorf("failed to switch context: %w", msg.err))
+			break
+		}
+		cmds = append(cmds, m.setClient(msg.client))
+
+	case deletePreviewMsg:
+		cmds = append(cmds, m.confirmDelete(msg))
+
+	case retryPreviewMsg:
+		cmds = append(cmds, m.confirmRetry(msg))
+
+	case actionResultMsg:
+		m.setStatus(msg.text, msg.err)
+		if err := m.recordAudit(msg.audit); err != nil {
+			m.setStatus("", fmt.Errorf("failed to write audit log: %w", err))
+		}
+		if msg.created != nil {
+			m.addCreated(*msg.created)
+		}
+		if msg.err == nil {
+			cmds = append(cmds, m.fetchResources())
+		}
+
+	case kubeEventsMsg:
+		cmds = append(cmds, m.mergeKubeEvents(msg))
+
+	case logLinesMsg:
+		if m.showDetail {
+			cmds = append(cmds, m.detail.appendLogs(msg))
+		}
+
+	case animTickMsg:
+		if m.showDetail {
+			cmds = append(cmds, m.detail.animate())
+		} else {
+			m.detail.animating = false
+		}
 
 	case errMsg:
 		m.err = msg.error
@@ -349,7 +683,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// setViewMode switches tabs; the K8s Events tab starts the event stream
+func (m *Model) setViewMode(v types.ViewMode) tea.Cmd {
+	m.viewMode = v
+	m.updateFiltered()
+	if v == types.ViewKubeEvents {
+		return m.startEventStream()
+	}
+	return nil
+}
+
 func (m *Model) updateFiltered() {
+	// Remember the selection so the cursor can follow it after the rebuild
+	prevCache := m.filteredCache
+	prevCursor := m.cursor
+
 	filtered := m.filterResources()
 
 	// Separate parents and children
@@ -358,7 +706,7 @@ func (m *Model) updateFiltered() {
 
 	for _, r := range filtered {
 		if r.ParentName != "" {
-			key := r.Namespace + "/" + r.ParentName
+			key := treeKey(r.Namespace, r.ParentName)
 			childrenMap[key] = append(childrenMap[key], r)
 		} else {
 			parents = append(parents, r)
@@ -416,19 +764,35 @@ func (m *Model) updateFiltered() {
 	// Build final list with tree structure
 	var result []types.AsyncResource
 	var prefixes []string
+	var summaries []string
 
 	for _, parent := range parents {
+		key := treeKey(parent.Namespace, parent.Name)
+		children := childrenMap[key]
+
 		result = append(result, parent)
-		prefixes = append(prefixes, "")
+		switch {
+		case len(children) == 0:
+			prefixes = append(prefixes, "")
+			summaries = append(summaries, "")
+		case m.expanded[key]:
+			prefixes = append(prefixes, "▾ ")
+			summaries = append(summaries, "")
+		default:
+			// Collapsed: hide children and show their history inline
+			prefixes = append(prefixes, "▸ ")
+			summaries = append(summaries, runHistorySummary(children))
+		}
 
-		key := parent.Namespace + "/" + parent.Name
-		children := childrenMap[key]
-		for i, child := range children {
-			result = append(result, child)
-			if i == len(children)-1 {
-				prefixes = append(prefixes, "┗ ")
-			} else {
-				prefixes = append(prefixes, "┣ ")
+		if m.expanded[key] {
+			for i, child := range children {
+				result = append(result, child)
+				summaries = append(summaries, "")
+				if i == len(children)-1 {
+					prefixes = append(prefixes, "┗ ")
+				} else {
+					prefixes = append(prefixes, "┣ ")
+				}
 			}
 		}
 		// Remove used children
@@ -440,11 +804,15 @@ func (m *Model) updateFiltered() {
 		for _, child := range children {
 			result = append(result, child)
 			prefixes = append(prefixes, "")
+			summaries = append(summaries, "")
 		}
 	}
 
 	m.filteredCache = result
 	m.treePrefixes = prefixes
+	m.treeSummaries = summaries
+
+	m.cursor = followSelection(prevCache, prevCursor, result)
 
 	// Adjust cursor if needed
 	if m.cursor >= len(m.filteredCache) {
@@ -455,6 +823,114 @@ func (m *Model) updateFiltered() {
 	}
 }
 
+// refreshDetail updates the open detail view with the latest copy of its resource
+func (m *Model) refreshDetail() tea.Cmd {
+	id := resourceID(m.detail.resource)
+	for _, r := range m.resources {
+		if resourceID(r) == id {
+			return m.detail.setResource(r)
+		}
+	}
+	m.detail.markGone()
+	return nil
+}
+
+// resourceID returns the identity of a resource, preferring its UID
+func resourceID(r types.AsyncResource) string {
+	if r.UID != "" {
+		return r.UID
+	}
+	return string(r.Kind) + "/" + r.Namespace + "/" + r.Name
+}
+
+// followSelection returns the index in next of the resource selected at
+// cursor in prev. If that resource is gone, the nearest surviving neighbor
+// in prev is selected instead.
+func followSelection(prev []types.AsyncResource, cursor int, next []types.AsyncResource) int {
+	if cursor < 0 || cursor >= len(prev) {
+		return cursor
+	}
+
+	index := make(map[string]int, len(next))
+	for i, r := range next {
+		index[resourceID(r)] = i
+	}
+
+	for d := 0; d < len(prev); d++ {
+		for _, i := range []int{cursor + d, cursor - d} {
+			if i < 0 || i >= len(prev) {
+				continue
+			}
+			if idx, ok := index[resourceID(prev[i])]; ok {
+				return idx
+			}
+		}
+	}
+	return cursor
+}
+
+// treeKey returns the key used to group children under their parent
+func treeKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// setExpanded expands or collapses the tree group at the cursor.
+// On a child row, collapsing folds its parent and moves the cursor there.
+func (m *Model) setExpanded(expand bool) {
+	if m.cursor < 0 || m.cursor >= len(m.filteredCache) {
+		return
+	}
+	r := m.filteredCache[m.cursor]
+
+	if r.ParentName == "" {
+		m.expanded[treeKey(r.Namespace, r.Name)] = expand
+		m.updateFiltered()
+		return
+	}
+
+	if expand {
+		return
+	}
+	m.expanded[treeKey(r.Namespace, r.ParentName)] = false
+	for i := m.cursor; i >= 0; i-- {
+		p := m.filteredCache[i]
+		if p.ParentName == "" && p.Namespace == r.Namespace && p.Name == r.ParentName {
+			m.cursor = i
+			break
+		}
+	}
+	m.updateFiltered()
+}
+
+// maxHistoryRuns limits the number of runs shown in a collapsed history strip
+const maxHistoryRuns = 8
+
+// runHistorySummary builds an inline history strip (oldest to newest) plus counts.
+// children must be sorted newest first.
+func runHistorySummary(children []types.AsyncResource) string {
+	counts := make(map[types.ResourceStatus]int)
+	for _, c := range children {
+		counts[c.Status]++
+	}
+
+	n := len(children)
+	if n > maxHistoryRuns {
+		n = maxHistoryRuns
+	}
+	var strip strings.Builder
+	for i := n - 1; i >= 0; i-- {
+		strip.WriteString(statusIcon(children[i].Status))
+	}
+
+	parts := []string{strip.String()}
+	for _, s := range []types.ResourceStatus{types.StatusSucceeded, types.StatusFailed, types.StatusRunning, types.StatusPending} {
+		if counts[s] > 0 {
+			parts = append(parts, fmt.Sprintf("%s%d", statusIcon(s), counts[s]))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
 // getNextRunTimeValue returns the next run time as time.Time for sorting
 // If timezone is specified, schedule is interpreted in that timezone
 func (m *Model) getNextRunTimeValue(schedule, timezone string, parser cron.Parser) time.Time {
@@ -483,25 +959,15 @@ func (m *Model) getNextRunTimeValue(schedule, timezone string, parser cron.Parse
 }
 
 func (m Model) filterResources() []types.AsyncResource {
-	if m.viewMode == types.ViewAll {
-		return m.resources
+	listed := make(map[types.ResourceKind]bool)
+	for _, k := range kinds.InView(m.viewMode) {
+		listed[k.Kind] = true
 	}
 
 	var filtered []types.AsyncResource
 	for _, r := range m.resources {
-		switch m.viewMode {
-		case types.ViewJobs:
-			if r.Kind == types.KindJob || r.Kind == types.KindCronJob {
-				filtered = append(filtered, r)
-			}
-		case types.ViewWorkflows:
-			if r.Kind == types.KindWorkflow || r.Kind == types.KindCronWorkflow {
-				filtered = append(filtered, r)
-			}
-		case types.ViewEvents:
-			if r.Kind == types.KindSensor || r.Kind == types.KindEventSource {
-				filtered = append(filtered, r)
-			}
+		if listed[r.Kind] {
+			filtered = append(filtered, r)
 		}
 	}
 	return filtered
@@ -517,8 +983,10 @@ func statusPriority(s types.ResourceStatus) int {
 		return 2
 	case types.StatusSucceeded:
 		return 3
-	default:
+	case types.StatusSuspended:
 		return 4
+	default:
+		return 5
 	}
 }
 
@@ -528,8 +996,8 @@ func (m Model) View() string {
 	}
 
 	// Show detail view if active
-	if m.showDetail && m.selectedResource != nil {
-		return RenderDetail(*m.selectedResource, m.width, m.height)
+	if m.showDetail {
+		return m.detail.view()
 	}
 
 	// Title
@@ -548,8 +1016,21 @@ func (m Model) View() string {
 	// Tabs
 	tabs := m.renderTabs()
 
-	// Table
-	tableView := m.renderTable()
+	// Table, with the live detail pane beside or below it
+	tableView := m.renderBody(width)
+	if m.showHistory {
+		tableView = m.renderHistory(width, max(m.height-10, 5))
+	}
+	if m.showQueues {
+		tableView = m.renderQueues(width, max(m.height-10, 5))
+	}
+	if m.contextPicker != nil {
+		tableView = m.renderContextPicker(max(m.height-14, 5))
+	}
+	if m.confirmation != nil {
+		tableView = m.renderConfirm()
+	}
+	statusLine := clipToWidth(m.renderStatusLine(), width)
 
 	// Help
 	var helpView string
@@ -566,6 +1047,7 @@ func (m Model) View() string {
 		separator,
 		tabs,
 		tableView,
+		statusLine,
 		helpView,
 	)
 }
@@ -593,15 +1075,30 @@ func (m Model) renderInfoLine() string {
 
 	sortStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("171")).Bold(true)
 
-	return fmt.Sprintf("%s %s  %s %s  %s %s  %s %s  %s %s  %s %s  %s %s",
+	mode := lipgloss.NewStyle().Foreground(lipgloss.Color("34")).Render("read-write")
+	switch {
+	case m.readOnlyReason() != "":
+		mode = readOnlyBadgeStyle.Render("READ-ONLY")
+	case m.cfg.IsProduction(ctx):
+		mode = warnBadgeStyle.Render("PROTECTED")
+	}
+
+	countLabel, count := "resources:", len(m.filteredCache)
+	if m.viewMode == types.ViewKubeEvents {
+		countLabel, count = "events:", len(m.kubeEventRows)
+	}
+
+	return fmt.Sprintf("%s %s  %s %s  %s %s  %s %s  %s %s  %s %s  %s %s  %s %s",
 		labelStyle.Render("ctx:"),
 		ctxStyle.Render(ctx),
 		labelStyle.Render("cluster:"),
 		clusterStyle.Render(cluster),
 		labelStyle.Render("ns:"),
 		nsStyle.Render(ns),
-		labelStyle.Render("resources:"),
-		countStyle.Render(fmt.Sprintf("%d", len(m.filteredCache))),
+		labelStyle.Render("mode:"),
+		mode,
+		labelStyle.Render(countLabel),
+		countStyle.Render(fmt.Sprintf("%d", count)),
 		labelStyle.Render("tz:"),
 		tzStyle.Render(tz),
 		labelStyle.Render("sort:"),
@@ -611,23 +1108,81 @@ func (m Model) renderInfoLine() string {
 	)
 }
 
-func (m Model) renderTable() string {
-	var b strings.Builder
+// Pane layouts for split mode
+type paneLayout int
 
-	width := m.width
-	if width <= 0 {
-		width = 120
+const (
+	layoutSingle paneLayout = iota
+	layoutRight
+	layoutBottom
+)
+
+const (
+	minRightPaneWidth = 160 // terminal width needed for a side pane
+	minBottomPaneRows = 30  // terminal height needed for a bottom pane
+	bottomPaneHeight  = 12
+)
+
+// layout returns the pane layout that fits the current terminal size
+func (m Model) layout() paneLayout {
+	switch {
+	case !m.splitPane:
+		return layoutSingle
+	case m.width >= minRightPaneWidth:
+		return layoutRight
+	case m.height >= minBottomPaneRows:
+		return layoutBottom
+	default:
+		return layoutSingle
+	}
+}
+
+// renderBody renders the table, plus the detail pane of the highlighted row in split mode
+func (m Model) renderBody(width int) string {
+	// Calculate visible rows
+	maxRows := m.height - 10
+	if maxRows < 5 {
+		maxRows = 5
+	}
+
+	var selected *types.AsyncResource
+	if m.viewMode == types.ViewKubeEvents {
+		selected = m.kubeEventResource(m.selectedKubeEvent())
+	} else if m.cursor >= 0 && m.cursor < len(m.filteredCache) {
+		selected = &m.filteredCache[m.cursor]
+	}
+
+	switch m.layout() {
+	case layoutRight:
+		paneWidth := max(width/3, 50)
+		table := m.renderTable(width-paneWidth, maxRows)
+		pane := renderCompactDetail(selected, paneWidth, maxRows+1)
+		return lipgloss.JoinHorizontal(lipgloss.Top, table, pane)
+	case layoutBottom:
+		table := m.renderTable(width, max(maxRows-bottomPaneHeight, 5))
+		pane := renderCompactDetail(selected, width, bottomPaneHeight)
+		return lipgloss.JoinVertical(lipgloss.Left, table, pane)
+	default:
+		return m.renderTable(width, maxRows)
+	}
+}
+
+func (m Model) renderTable(width, maxRows int) string {
+	if m.viewMode == types.ViewKubeEvents {
+		return m.renderKubeEventTable(width, maxRows)
 	}
 
+	var b strings.Builder
+
 	// Header - clip to screen width
 	header := m.renderHeader()
 	b.WriteString(clipToWidth(header, width))
 	b.WriteString("\n")
 
-	// Calculate visible rows
-	maxRows := m.height - 10
-	if maxRows < 5 {
-		maxRows = 5
+	if forbidden := m.renderForbidden(); forbidden != "" {
+		b.WriteString(clipToWidth(forbidden, width))
+		b.WriteString("\n")
+		maxRows--
 	}
 
 	startIdx := 0
@@ -649,7 +1204,12 @@ func (m Model) renderTable() string {
 			prefix = m.treePrefixes[i]
 		}
 
-		row := m.renderRow(r, isSelected, prefix)
+		summary := ""
+		if i < len(m.treeSummaries) {
+			summary = m.treeSummaries[i]
+		}
+
+		row := m.renderRow(r, isSelected, prefix, summary)
 		b.WriteString(clipToWidth(row, width))
 		b.WriteString("\n")
 	}
@@ -659,12 +1219,21 @@ func (m Model) renderTable() string {
 
 func (m Model) getColumnConfig() ([]int, []string) {
 	switch m.viewMode {
-	case types.ViewEvents:
-		return colWidthsEvents, colHeadersEvents
+	case types.ViewKubeEvents:
+		return colWidthsKubeEvents, colHeadersKubeEvents
 	case types.ViewAll:
 		return colWidthsAll, colHeadersAll
-	default: // ViewJobs, ViewWorkflows
+	case types.ViewJobs, types.ViewWorkflows:
 		return colWidthsJobs, colHeadersJobs
+	default:
+		// Common columns, then those of the tab's kinds
+		widths := append([]int(nil), colWidthsKinds...)
+		headers := append([]string(nil), colHeadersKinds...)
+		for _, c := range kinds.ViewColumns(m.viewMode) {
+			widths = append(widths, c.Width)
+			headers = append(headers, c.Header)
+		}
+		return append(widths, colWidthRuns), append(headers, "RUNS")
 	}
 }
 
@@ -680,7 +1249,7 @@ func (m Model) renderHeader() string {
 	for i, h := range colHeaders {
 		header := h
 		// Add timezone to LAST and NEXT columns (Jobs/Workflows view)
-		if m.viewMode != types.ViewEvents && m.viewMode != types.ViewAll {
+		if m.viewMode == types.ViewJobs || m.viewMode == types.ViewWorkflows {
 			if header == "LAST" {
 				header = fmt.Sprintf("LAST(%s)", tz)
 			} else if header == "NEXT" {
@@ -698,7 +1267,7 @@ func clipToWidth(s string, width int) string {
 	return lipgloss.NewStyle().MaxWidth(width).Render(s)
 }
 
-func (m Model) renderRow(r types.AsyncResource, isSelected bool, treePrefix string) string {
+func (m Model) renderRow(r types.AsyncResource, isSelected bool, treePrefix, summary string) string {
 	colWidths, _ := m.getColumnConfig()
 
 	duration := "-"
@@ -711,6 +1280,12 @@ func (m Model) renderRow(r types.AsyncResource, isSelected bool, treePrefix stri
 
 	var cells []string
 	statusColIdx := 3 // Status column index for coloring
+	warnColIdx := 4   // Warning badge column index
+
+	warn := "-"
+	if r.WarningCount > 0 {
+		warn = fmt.Sprintf("⚠%d", r.WarningCount)
+	}
 
 	// Format service account
 	sa := r.ServiceAccount
@@ -720,62 +1295,27 @@ func (m Model) renderRow(r types.AsyncResource, isSelected bool, treePrefix stri
 
 	switch m.viewMode {
 	case types.ViewAll:
-		// All view: KIND, NAMESPACE, NAME, STATUS, SA, DURATION, MESSAGE
-		msg := truncateMsg(r.Message, colWidths[6]-2)
-		cells = []string{
-			padRight(kindStr, colWidths[0]),
-			padRight(truncate(r.Namespace, colWidths[1]-2), colWidths[1]),
-			padRight(truncate(r.Name, colWidths[2]-2), colWidths[2]),
-			padRight(formatStatusText(r.Status), colWidths[3]),
-			padRight(truncate(sa, colWidths[4]-2), colWidths[4]),
-			padRight(duration, colWidths[5]),
-			padRight(msg, colWidths[6]),
-		}
-
-	case types.ViewEvents:
-		// Events view: KIND, NAMESPACE, NAME, STATUS, SA, EVENT_SOURCE, EVENT_NAME, TRIGGER
-		eventSource := r.EventSourceName
-		if eventSource == "" {
-			eventSource = "-"
-		}
-		eventName := "-"
-		if len(r.EventNames) > 0 {
-			eventName = r.EventNames[0]
-			if len(r.EventNames) > 1 {
-				eventName += fmt.Sprintf(" (+%d)", len(r.EventNames)-1)
-			}
-		}
-		trigger := "-"
-		if len(r.TriggerNames) > 0 {
-			trigger = r.TriggerNames[0]
-			if len(r.TriggerNames) > 1 {
-				trigger += fmt.Sprintf(" (+%d)", len(r.TriggerNames)-1)
-			}
-		}
-		// For EventSource, show event type instead
-		if r.Kind == types.KindEventSource {
-			eventName = r.EventType
-			if eventName == "" {
-				eventName = "-"
-			}
-			trigger = "-"
-		}
+		// All view: KIND, NAMESPACE, NAME, STATUS, WARN, SA, DURATION, MESSAGE
+		msg := messageCell(r.Message, summary, colWidths[7])
 		cells = []string{
 			padRight(kindStr, colWidths[0]),
 			padRight(truncate(r.Namespace, colWidths[1]-2), colWidths[1]),
 			padRight(truncate(r.Name, colWidths[2]-2), colWidths[2]),
 			padRight(formatStatusText(r.Status), colWidths[3]),
-			padRight(truncate(sa, colWidths[4]-2), colWidths[4]),
-			padRight(truncate(eventSource, colWidths[5]-2), colWidths[5]),
-			padRight(truncate(eventName, colWidths[6]-2), colWidths[6]),
-			padRight(truncate(trigger, colWidths[7]-2), colWidths[7]),
+			padRight(warn, colWidths[4]),
+			padRight(truncate(sa, colWidths[5]-2), colWidths[5]),
+			padRight(duration, colWidths[6]),
+			padRight(msg, colWidths[7]),
 		}
 
-	default: // ViewJobs, ViewWorkflows
+	case types.ViewJobs, types.ViewWorkflows:
 		// Jobs/Workflows view: full schedule columns
 		cronFields := parseCronFields(r.Schedule)
 		lastRun := m.formatTime(r.LastRun)
 		nextRun := m.getNextRunTime(r.Schedule, r.Timezone)
+		if r.Suspended {
+			nextRun = "-"
+		}
 
 		tz := r.Timezone
 		if tz == "" {
@@ -786,24 +1326,45 @@ func (m Model) renderRow(r types.AsyncResource, isSelected bool, treePrefix stri
 			tz = strings.TrimPrefix(tz, "Europe/")
 		}
 
-		msg := truncateMsg(r.Message, colWidths[14]-2)
+		msg := messageCell(r.Message, summary, colWidths[15])
+		cells = []string{
+			padRight(kindStr, colWidths[0]),
+			padRight(truncate(r.Namespace, colWidths[1]-2), colWidths[1]),
+			padRight(truncate(r.Name, colWidths[2]-2), colWidths[2]),
+			padRight(formatStatusText(r.Status), colWidths[3]),
+			padRight(warn, colWidths[4]),
+			padRight(truncate(sa, colWidths[5]-2), colWidths[5]), // SA
+			padRight(duration, colWidths[6]),
+			padCenter(cronFields[0], colWidths[7]),  // MIN
+			padCenter(cronFields[1], colWidths[8]),  // HRS
+			padCenter(cronFields[2], colWidths[9]),  // DAY
+			padCenter(cronFields[3], colWidths[10]), // MON
+			padCenter(cronFields[4], colWidths[11]), // DOW
+			padRight(tz, colWidths[12]),             // TZ
+			padRight(lastRun, colWidths[13]),        // LAST
+			padRight(nextRun, colWidths[14]),        // NEXT
+			padRight(msg, colWidths[15]),
+		}
+
+	default:
+		// Common columns, then the columns the tab's kinds contribute
 		cells = []string{
 			padRight(kindStr, colWidths[0]),
 			padRight(truncate(r.Namespace, colWidths[1]-2), colWidths[1]),
 			padRight(truncate(r.Name, colWidths[2]-2), colWidths[2]),
 			padRight(formatStatusText(r.Status), colWidths[3]),
-			padRight(truncate(sa, colWidths[4]-2), colWidths[4]), // SA
-			padRight(duration, colWidths[5]),
-			padCenter(cronFields[0], colWidths[6]),  // MIN
-			padCenter(cronFields[1], colWidths[7]),  // HRS
-			padCenter(cronFields[2], colWidths[8]),  // DAY
-			padCenter(cronFields[3], colWidths[9]),  // MON
-			padCenter(cronFields[4], colWidths[10]), // DOW
-			padRight(tz, colWidths[11]),             // TZ
-			padRight(lastRun, colWidths[12]),        // LAST
-			padRight(nextRun, colWidths[13]),        // NEXT
-			padRight(msg, colWidths[14]),
+			padRight(warn, colWidths[4]),
+			padRight(truncate(sa, colWidths[5]-2), colWidths[5]),
+		}
+		for i, c := range kinds.ViewColumns(m.viewMode) {
+			value := kinds.ColumnValue(r, c.Header)
+			if value == "" {
+				value = "-"
+			}
+			w := colWidths[len(colWidthsKinds)+i]
+			cells = append(cells, padRight(truncate(value, w-2), w))
 		}
+		cells = append(cells, padRight(messageCell("", summary, colWidthRuns), colWidthRuns))
 	}
 
 	var result strings.Builder
@@ -813,6 +1374,8 @@ func (m Model) renderRow(r types.AsyncResource, isSelected bool, treePrefix stri
 		} else if i == statusColIdx {
 			// Status column - apply background color
 			result.WriteString(getStatusStyle(r.Status).Render(cell))
+		} else if i == warnColIdx && r.WarningCount > 0 {
+			result.WriteString(warnBadgeStyle.Render(cell))
 		} else {
 			result.WriteString(cellStyle.Render(cell))
 		}
@@ -821,6 +1384,15 @@ func (m Model) renderRow(r types.AsyncResource, isSelected bool, treePrefix stri
 	return result.String()
 }
 
+// messageCell returns the text of a MESSAGE column: the run-history strip
+// of a collapsed group if any, else the message
+func messageCell(msg, summary string, width int) string {
+	if summary != "" {
+		return ansi.Truncate(summary, width-2, "…")
+	}
+	return truncateMsg(msg, width-2)
+}
+
 func truncateMsg(msg string, maxLen int) string {
 	if msg == "" {
 		return "-"
@@ -930,11 +1502,30 @@ func getStatusStyle(s types.ResourceStatus) lipgloss.Style {
 		return base.Background(failedBg)
 	case types.StatusPending:
 		return base.Background(pendingBg)
+	case types.StatusSuspended:
+		return base.Background(suspendedBg)
 	default:
 		return base.Background(unknownBg)
 	}
 }
 
+func statusIcon(s types.ResourceStatus) string {
+	switch s {
+	case types.StatusRunning:
+		return "●"
+	case types.StatusSucceeded:
+		return "✓"
+	case types.StatusFailed:
+		return "✗"
+	case types.StatusPending:
+		return "○"
+	case types.StatusSuspended:
+		return "⏸"
+	default:
+		return "?"
+	}
+}
+
 func formatStatusText(s types.ResourceStatus) string {
 	switch s {
 	case types.StatusRunning:
@@ -945,6 +1536,8 @@ func formatStatusText(s types.ResourceStatus) string {
 		return "✗ Failed"
 	case types.StatusPending:
 		return "○ Pending"
+	case types.StatusSuspended:
+		return "⏸ Suspended"
 	default:
 		return "? Unknown"
 	}
@@ -968,14 +1561,22 @@ func formatDuration(d time.Duration) string {
 }
 
 func (m Model) renderTabs() string {
-	tabs := []string{"All", "Jobs", "Workflows", "Events"}
+	tabs := []string{"All", "Jobs", "Workflows", "Events", "K8s Events"}
 	var rendered []string
 
 	for i, tab := range tabs {
+		label := fmt.Sprintf("%d:%s", i+1, tab)
+		// Tabs whose kinds are all forbidden or not installed
+		v := types.ViewMode(i)
+		if len(m.absentKinds(v)) == len(viewKinds(v)) {
+			label += " (n/a)"
+		} else if len(m.forbiddenKinds(v)) == len(viewKinds(v)) {
+			label += " ⊘"
+		}
 		if types.ViewMode(i) == m.viewMode {
-			rendered = append(rendered, tabActiveStyle.Render(fmt.Sprintf("%d:%s", i+1, tab)))
+			rendered = append(rendered, tabActiveStyle.Render(label))
 		} else {
-			rendered = append(rendered, tabInactiveStyle.Render(fmt.Sprintf("%d:%s", i+1, tab)))
+			rendered = append(rendered, tabInactiveStyle.Render(label))
 		}
 	}
 
@@ -987,11 +1588,11 @@ func (m Model) fetchResources() tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		resources, err := m.k8sClient.ListAll(ctx)
+		resources, forbidden, failed, err := m.k8sClient.ListAll(ctx)
 		if err != nil {
 			return errMsg{err}
 		}
-		return resourcesMsg(resources)
+		return resourcesMsg{client: m.k8sClient, resources: resources, forbidden: forbidden, failed: failed}
 	}
 }
 
@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/ginbear/k8s-flowtop/internal/types"
+)
+
+func TestRunHistorySummary(t *testing.T) {
+	runs := func(statuses ...types.ResourceStatus) []types.AsyncResource {
+		rs := make([]types.AsyncResource, len(statuses))
+		for i, s := range statuses {
+			rs[i] = types.AsyncResource{Status: s}
+		}
+		return rs
+	}
+
+	tests := []struct {
+		name     string
+		children []types.AsyncResource
+		want     string
+	}{
+		{
+			name: "no runs",
+			want: "",
+		},
+		{
+			name:     "oldest first",
+			children: runs(types.StatusRunning, types.StatusFailed, types.StatusSucceeded),
+			want:     "✓✗● ✓1 ✗1 ●1",
+		},
+		{
+			name: "strip is capped, counts are not",
+			children: runs(
+				types.StatusFailed,
+				types.StatusSucceeded, types.StatusSucceeded, types.StatusSucceeded,
+				types.StatusSucceeded, types.StatusSucceeded, types.StatusSucceeded,
+				types.StatusSucceeded, types.StatusPending, types.StatusPending,
+			),
+			want: "✓✓✓✓✓✓✓✗ ✓7 ✗1 ○2",
+		},
+		{
+			name:     "suspended and unknown runs are not counted",
+			children: runs(types.StatusSuspended, types.StatusUnknown),
+			want:     "?⏸",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runHistorySummary(tt.children); got != tt.want {
+				t.Errorf("runHistorySummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFollowSelection(t *testing.T) {
+	rows := func(names ...string) []types.AsyncResource {
+		rs := make([]types.AsyncResource, len(names))
+		for i, n := range names {
+			rs[i] = types.AsyncResource{Kind: types.KindJob, Namespace: "default", Name: n}
+		}
+		return rs
+	}
+
+	tests := []struct {
+		name   string
+		prev   []types.AsyncResource
+		cursor int
+		next   []types.AsyncResource
+		want   int
+	}{
+		{"same order", rows("a", "b", "c"), 1, rows("a", "b", "c"), 1},
+		{"row moved", rows("a", "b", "c"), 1, rows("b", "c", "a"), 0},
+		{"row inserted above", rows("a", "b"), 1, rows("new", "a", "b"), 2},
+		{"gone, next neighbor survives", rows("a", "b", "c"), 1, rows("a", "c"), 1},
+		{"gone, only previous neighbor survives", rows("a", "b", "c"), 2, rows("a"), 0},
+		{"all gone", rows("a", "b"), 1, rows("x"), 1},
+		{"cursor out of range", rows("a"), 5, rows("a"), 5},
+		{"empty list", nil, 0, rows("a"), 0},
+		{
+			name:   "matched by UID",
+			prev:   []types.AsyncResource{{UID: "1", Name: "old-name"}, {UID: "2", Name: "b"}},
+			cursor: 0,
+			next:   []types.AsyncResource{{UID: "2", Name: "b"}, {UID: "1", Name: "new-name"}},
+			want:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := followSelection(tt.prev, tt.cursor, tt.next); got != tt.want {
+				t.Errorf("followSelection() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
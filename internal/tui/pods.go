@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+)
+
+// Pods tab layout
+const podContainerW = 20
+
+var reasonStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+
+// renderPods lists the pods of the resource with their container states
+func (d detailView) renderPods(width int) string {
+	if d.podsErr != nil {
+		return detailHintStyle.Render(fmt.Sprintf("Failed to list pods: %v", d.podsErr))
+	}
+	if d.pods == nil {
+		return detailHintStyle.Render("Loading...")
+	}
+	if len(d.pods) == 0 {
+		return detailHintStyle.Render(fmt.Sprintf("No pods found for %s.", d.resource.Kind))
+	}
+
+	var b strings.Builder
+	for i, pod := range d.pods {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(renderPodHeader(pod, width))
+		b.WriteString("\n")
+		for _, cs := range pod.Statuses {
+			b.WriteString(renderContainerStatus(cs, width))
+			b.WriteString("\n")
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// renderPodHeader renders the summary line of a pod
+func renderPodHeader(pod types.PodInfo, width int) string {
+	style := lipgloss.NewStyle().Foreground(phaseColor(pod.Phase))
+	line := style.Render(phaseIcon(pod.Phase)+" "+pod.Name) + " " + style.Render(pod.Phase)
+	if pod.Reason != "" {
+		line += " " + reasonStyle.Render(pod.Reason)
+	}
+
+	var meta []string
+	if pod.NodeName != "" {
+		meta = append(meta, "step: "+pod.NodeName)
+	}
+	if pod.HostNodeName != "" {
+		meta = append(meta, "node: "+pod.HostNodeName)
+	}
+	meta = append(meta, fmt.Sprintf("restarts: %d", pod.Restarts))
+	if !pod.CreatedAt.IsZero() {
+		meta = append(meta, "age: "+formatDuration(time.Since(pod.CreatedAt)))
+	}
+	line += "  " + detailHintStyle.Render(strings.Join(meta, "  "))
+	return ansi.Truncate(line, width, "…")
+}
+
+// renderContainerStatus renders a container line with its state and reason
+func renderContainerStatus(cs types.ContainerStatus, width int) string {
+	name := cs.Name
+	if cs.Init {
+		name += " (init)"
+	}
+
+	stateColor := lipgloss.Color("241")
+	switch {
+	case cs.State == "Running":
+		stateColor = phaseColor("Running")
+	case cs.State == "Terminated" && cs.ExitCode == 0:
+		stateColor = phaseColor("Succeeded")
+	case cs.State == "Terminated" || (cs.State == "Waiting" && cs.Reason != "" && cs.Reason != "ContainerCreating" && cs.Reason != "PodInitializing"):
+		stateColor = phaseColor("Failed")
+	}
+
+	line := "    " + padRight(ansi.Truncate(name, podContainerW, "…"), podContainerW) + " " +
+		lipgloss.NewStyle().Foreground(stateColor).Render(padRight(cs.State, 10))
+
+	var details []string
+	if cs.Reason != "" && cs.Reason != "Completed" {
+		details = append(details, reasonStyle.Render(cs.Reason))
+	}
+	if cs.State == "Terminated" {
+		details = append(details, fmt.Sprintf("exit %d", cs.ExitCode))
+	}
+	if cs.Restarts > 0 {
+		details = append(details, fmt.Sprintf("restarts %d", cs.Restarts))
+	}
+	if cs.LastReason != "" {
+		details = append(details, detailHintStyle.Render(fmt.Sprintf("last: %s (exit %d)", cs.LastReason, cs.LastExitCode)))
+	}
+	if cs.Message != "" {
+		details = append(details, detailHintStyle.Render(strings.ReplaceAll(cs.Message, "\n", " ")))
+	}
+	if len(details) > 0 {
+		line += " " + strings.Join(details, "  ")
+	}
+	return ansi.Truncate(line, width, "…")
+}
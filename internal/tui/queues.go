@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ginbear/k8s-flowtop/internal/k8s"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+)
+
+// Queue overview column widths: QUEUE, STATUS, COHORT, PENDING, RESERVING, ADMITTED, USAGE
+var queueColWidths = []int{32, 14, 14, 9, 11, 10, 60}
+
+// queueRow is a ClusterQueue, or a LocalQueue listed under its ClusterQueue
+type queueRow struct {
+	queue types.AsyncResource
+	local bool
+}
+
+// queueRows returns the ClusterQueues, each followed by the LocalQueues
+// that submit to it; LocalQueues of unlisted ClusterQueues come last
+func (m Model) queueRows() []queueRow {
+	var clusterQueues []types.AsyncResource
+	localQueues := make(map[string][]types.AsyncResource)
+	for _, r := range m.resources {
+		switch r.Kind {
+		case k8s.KindClusterQueue:
+			clusterQueues = append(clusterQueues, r)
+		case k8s.KindLocalQueue:
+			localQueues[r.Attributes["clusterQueue"]] = append(localQueues[r.Attributes["clusterQueue"]], r)
+		}
+	}
+	sort.Slice(clusterQueues, func(i, j int) bool {
+		return clusterQueues[i].Name < clusterQueues[j].Name
+	})
+
+	var rows []queueRow
+	addLocal := func(cq string) {
+		lqs := localQueues[cq]
+		sort.Slice(lqs, func(i, j int) bool {
+			return lqs[i].Namespace+"/"+lqs[i].Name < lqs[j].Namespace+"/"+lqs[j].Name
+		})
+		for _, lq := range lqs {
+			rows = append(rows, queueRow{queue: lq, local: true})
+		}
+		delete(localQueues, cq)
+	}
+	for _, cq := range clusterQueues {
+		rows = append(rows, queueRow{queue: cq})
+		addLocal(cq.Name)
+	}
+	orphans := make([]string, 0, len(localQueues))
+	for cq := range localQueues {
+		orphans = append(orphans, cq)
+	}
+	sort.Strings(orphans)
+	for _, cq := range orphans {
+		addLocal(cq)
+	}
+	return rows
+}
+
+// updateQueues handles keys while the queue overview is open
+func (m *Model) updateQueues(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k":
+		if m.queuesOffset > 0 {
+			m.queuesOffset--
+		}
+	case "down", "j":
+		if m.queuesOffset < len(m.queueRows())-1 {
+			m.queuesOffset++
+		}
+	case "Q", "esc":
+		m.showQueues = false
+	case "q", "ctrl+c":
+		return tea.Quit
+	}
+	return nil
+}
+
+// renderQueues renders pending and admitted workloads and quota usage
+// per ClusterQueue
+func (m Model) renderQueues(width, maxRows int) string {
+	var b strings.Builder
+	b.WriteString(detailTitleStyle.MarginBottom(0).Render("Kueue queues"))
+	b.WriteString("\n")
+
+	rows := m.queueRows()
+	if len(rows) == 0 {
+		msg := "No Kueue queues found."
+		if !m.k8sClient.APIs().Installed(k8s.KindClusterQueue) {
+			msg = "Kueue is not installed on this cluster."
+		} else if m.forbidden[k8s.KindClusterQueue] {
+			msg = "ClusterQueues are forbidden."
+		} else if err := m.listErrors[k8s.KindClusterQueue]; err != nil {
+			msg = fmt.Sprintf("ClusterQueues could not be listed: %v", err)
+		}
+		b.WriteString(detailHintStyle.Render(msg))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	var header strings.Builder
+	for i, h := range []string{"QUEUE", "STATUS", "COHORT", "PENDING", "RESERVING", "ADMITTED", "USAGE"} {
+		header.WriteString(headerStyle.Render(padRight(h, queueColWidths[i])))
+	}
+	b.WriteString(clipToWidth(header.String(), width))
+	b.WriteString("\n")
+
+	end := min(m.queuesOffset+maxRows-1, len(rows))
+	for _, row := range rows[m.queuesOffset:end] {
+		b.WriteString(clipToWidth(renderQueueRow(row), width))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderQueueRow renders a single queue
+func renderQueueRow(row queueRow) string {
+	q := row.queue
+	name := q.Name
+	if row.local {
+		name = "└ " + q.Namespace + "/" + q.Name
+	}
+	value := func(key string) string {
+		if v := q.Attributes[key]; v != "" {
+			return v
+		}
+		return "-"
+	}
+
+	cells := []string{
+		padRight(truncate(name, queueColWidths[0]-2), queueColWidths[0]),
+		padRight(formatStatusText(q.Status), queueColWidths[1]),
+		padRight(truncate(value("cohort"), queueColWidths[2]-2), queueColWidths[2]),
+		padRight(value("pending"), queueColWidths[3]),
+		padRight(value("reserving"), queueColWidths[4]),
+		padRight(value("admitted"), queueColWidths[5]),
+		padRight(truncateMsg(value("usage"), queueColWidths[6]-2), queueColWidths[6]),
+	}
+	var b strings.Builder
+	for i, c := range cells {
+		if i == 1 {
+			b.WriteString(getStatusStyle(q.Status).Render(c))
+			continue
+		}
+		b.WriteString(cellStyle.Render(c))
+	}
+	return b.String()
+}
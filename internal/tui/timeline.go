@@ -0,0 +1,195 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ginbear/k8s-flowtop/internal/types"
+)
+
+// Timeline layout
+const (
+	timelineLabelW    = 24
+	timelineDurationW = 9
+	animInterval      = 150 * time.Millisecond
+)
+
+var (
+	criticalStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("213")).Bold(true)
+	axisStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+)
+
+// runningFrames is the animated leading edge of running bars
+var runningFrames = []string{"▏", "▎", "▍", "▌", "▋", "▊", "▉", "█"}
+
+// animTickMsg advances animations in the detail view
+type animTickMsg time.Time
+
+func animTickCmd() tea.Cmd {
+	return tea.Tick(animInterval, func(t time.Time) tea.Msg {
+		return animTickMsg(t)
+	})
+}
+
+// isContainerNode reports whether a node only groups other nodes
+func isContainerNode(n types.DAGNode) bool {
+	switch n.Type {
+	case "DAG", "Steps", "StepGroup", "TaskGroup":
+		return true
+	default:
+		return false
+	}
+}
+
+// timelineNodes returns the executed nodes sorted by start time
+func timelineNodes(nodes []types.DAGNode) []types.DAGNode {
+	var result []types.DAGNode
+	for _, n := range nodes {
+		if n.StartedAt != nil && !isContainerNode(n) {
+			result = append(result, n)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if !result[i].StartedAt.Equal(*result[j].StartedAt) {
+			return result[i].StartedAt.Before(*result[j].StartedAt)
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// nodeEnd returns when a node finished, or now if it is still running
+func nodeEnd(n types.DAGNode, now time.Time) time.Time {
+	if n.FinishedAt != nil {
+		return *n.FinishedAt
+	}
+	if n.StartedAt != nil && n.Phase == "Running" {
+		return now
+	}
+	if n.StartedAt != nil {
+		return n.StartedAt.Add(n.Duration)
+	}
+	return time.Time{}
+}
+
+// criticalPath walks back from the node that finished last, following the
+// parent that finished latest (the one gating its start)
+func criticalPath(nodes []types.DAGNode, now time.Time) map[string]bool {
+	byID := make(map[string]types.DAGNode)
+	parents := make(map[string][]string)
+	for _, n := range nodes {
+		byID[n.ID] = n
+		for _, c := range n.Children {
+			parents[c] = append(parents[c], n.ID)
+		}
+	}
+
+	var last string
+	var lastEnd time.Time
+	for _, n := range timelineNodes(nodes) {
+		if end := nodeEnd(n, now); end.After(lastEnd) {
+			last, lastEnd = n.ID, end
+		}
+	}
+
+	path := make(map[string]bool)
+	for id := last; id != "" && !path[id]; {
+		path[id] = true
+		next := ""
+		var nextEnd time.Time
+		for _, p := range parents[id] {
+			if end := nodeEnd(byID[p], now); next == "" || end.After(nextEnd) {
+				next, nextEnd = p, end
+			}
+		}
+		id = next
+	}
+	return path
+}
+
+// hasRunningNodes reports whether any node is still running
+func hasRunningNodes(nodes []types.DAGNode) bool {
+	for _, n := range nodes {
+		if n.Phase == "Running" && !isContainerNode(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderTimeline renders a Gantt chart of node execution on a shared time axis
+func renderTimeline(nodes []types.DAGNode, width, frame int) string {
+	rows := timelineNodes(nodes)
+	if len(rows) == 0 {
+		return detailHintStyle.Render("No started nodes yet.")
+	}
+
+	now := time.Now()
+	start := *rows[0].StartedAt
+	end := start
+	for _, n := range rows {
+		if e := nodeEnd(n, now); e.After(end) {
+			end = e
+		}
+	}
+	span := end.Sub(start)
+	if span <= 0 {
+		span = time.Second
+	}
+
+	barW := max(width-timelineLabelW-timelineDurationW-2, 10)
+	critical := criticalPath(nodes, now)
+
+	var b strings.Builder
+
+	// Axis: start, middle and end offsets
+	axis := []rune(strings.Repeat("─", barW))
+	axis[0], axis[barW/2], axis[barW-1] = '┬', '┬', '┬'
+	b.WriteString(strings.Repeat(" ", timelineLabelW+1))
+	b.WriteString(axisStyle.Render(string(axis)))
+	b.WriteString("\n")
+	mid := "+" + formatDuration(span/2)
+	last := "+" + formatDuration(span)
+	labels := padRight("0s", barW/2-len(mid)/2) + mid
+	labels = padRight(labels, barW-len(last)) + last
+	b.WriteString(strings.Repeat(" ", timelineLabelW+1))
+	b.WriteString(axisStyle.Render(labels))
+	b.WriteString("\n")
+
+	for _, n := range rows {
+		from := int(float64(n.StartedAt.Sub(start)) / float64(span) * float64(barW))
+		to := int(float64(nodeEnd(n, now).Sub(start)) / float64(span) * float64(barW))
+		from = min(max(from, 0), barW-1)
+		to = min(max(to, from+1), barW)
+
+		style := lipgloss.NewStyle().Foreground(phaseColor(n.Phase))
+		bar := strings.Repeat("█", to-from)
+		if n.Phase == "Running" {
+			bar = strings.Repeat("█", to-from-1) + runningFrames[frame%len(runningFrames)]
+		}
+
+		label := phaseIcon(n.Phase) + " " + n.Name
+		if critical[n.ID] {
+			label = criticalStyle.Render(ansi.Truncate(label, timelineLabelW-2, "…") + " ★")
+		} else {
+			label = style.Render(ansi.Truncate(label, timelineLabelW, "…"))
+		}
+
+		b.WriteString(padRight(label, timelineLabelW))
+		b.WriteString(" ")
+		b.WriteString(strings.Repeat(" ", from))
+		b.WriteString(style.Render(bar))
+		b.WriteString(strings.Repeat(" ", barW-to))
+		b.WriteString(" ")
+		b.WriteString(detailHintStyle.Render(formatDuration(nodeEnd(n, now).Sub(*n.StartedAt))))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(criticalStyle.Render("★ critical path"))
+	return b.String()
+}
@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"sigs.k8s.io/yaml"
+)
+
+// Syntax highlighting styles
+var (
+	syntaxKeyStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("81"))
+	syntaxStringStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("114"))
+	syntaxScalarStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("215"))
+	syntaxPunctStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	searchMatchStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("16")).Background(lipgloss.Color("220"))
+)
+
+// objectScope selects which part of the raw object is shown
+type objectScope int
+
+const (
+	scopeFull objectScope = iota
+	scopeSpec
+	scopeStatus
+)
+
+func (s objectScope) String() string {
+	switch s {
+	case scopeSpec:
+		return "spec"
+	case scopeStatus:
+		return "status"
+	default:
+		return "full"
+	}
+}
+
+// formatObject renders the selected part of an object as YAML or JSON
+func formatObject(obj map[string]interface{}, scope objectScope, asJSON bool) (string, error) {
+	var data interface{} = obj
+	switch scope {
+	case scopeSpec:
+		data = obj["spec"]
+	case scopeStatus:
+		data = obj["status"]
+	}
+	if data == nil {
+		return "", fmt.Errorf("object has no %s", scope)
+	}
+
+	var out []byte
+	var err error
+	if asJSON {
+		out, err = json.MarshalIndent(data, "", "  ")
+	} else {
+		out, err = yaml.Marshal(data)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+// renderSearchable clips and highlights each line, marking lines that
+// contain query (case-insensitive). It returns the rendered text and the
+// indices of matching lines.
+func renderSearchable(text string, width int, highlight func(string) string, query string) (string, []int) {
+	lines := strings.Split(text, "\n")
+	var matches []int
+	lowerQuery := strings.ToLower(query)
+
+	for i, line := range lines {
+		line = ansi.Truncate(line, width, "…")
+		if query != "" && strings.Contains(strings.ToLower(line), lowerQuery) {
+			matches = append(matches, i)
+			lines[i] = highlightMatches(line, lowerQuery)
+			continue
+		}
+		lines[i] = highlight(line)
+	}
+	return strings.Join(lines, "\n"), matches
+}
+
+// highlightMatches marks every occurrence of query in line
+func highlightMatches(line, lowerQuery string) string {
+	var b strings.Builder
+	lower := strings.ToLower(line)
+	for {
+		i := strings.Index(lower, lowerQuery)
+		if i < 0 {
+			b.WriteString(line)
+			break
+		}
+		b.WriteString(line[:i])
+		b.WriteString(searchMatchStyle.Render(line[i : i+len(lowerQuery)]))
+		line = line[i+len(lowerQuery):]
+		lower = lower[i+len(lowerQuery):]
+	}
+	return b.String()
+}
+
+// highlightYAML colors a single line of YAML
+func highlightYAML(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	indent := line[:len(line)-len(trimmed)]
+
+	prefix := ""
+	if trimmed == "-" || strings.HasPrefix(trimmed, "- ") {
+		prefix = syntaxPunctStyle.Render("-")
+		trimmed = strings.TrimPrefix(strings.TrimPrefix(trimmed, "-"), " ")
+		if trimmed != "" {
+			prefix += " "
+		}
+	}
+
+	keyEnd := strings.Index(trimmed, ": ")
+	if keyEnd < 0 && strings.HasSuffix(trimmed, ":") {
+		keyEnd = len(trimmed) - 1
+	}
+	if keyEnd < 0 {
+		return indent + prefix + highlightScalar(trimmed)
+	}
+
+	key := trimmed[:keyEnd]
+	value := strings.TrimPrefix(trimmed[keyEnd+1:], " ")
+	out := indent + prefix + syntaxKeyStyle.Render(key) + syntaxPunctStyle.Render(":")
+	if value != "" {
+		out += " " + highlightScalar(value)
+	}
+	return out
+}
+
+// highlightJSON colors a single line of indented JSON
+func highlightJSON(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	indent := line[:len(line)-len(trimmed)]
+
+	if strings.HasPrefix(trimmed, `"`) {
+		if keyEnd := strings.Index(trimmed, `": `); keyEnd > 0 {
+			key := trimmed[:keyEnd+1]
+			value := trimmed[keyEnd+3:]
+			return indent + syntaxKeyStyle.Render(key) + syntaxPunctStyle.Render(":") + " " + highlightJSONValue(value)
+		}
+	}
+	return indent + highlightJSONValue(trimmed)
+}
+
+func highlightJSONValue(value string) string {
+	comma := ""
+	if strings.HasSuffix(value, ",") {
+		value = strings.TrimSuffix(value, ",")
+		comma = syntaxPunctStyle.Render(",")
+	}
+	switch {
+	case value == "{" || value == "}" || value == "[" || value == "]" || value == "{}" || value == "[]":
+		return syntaxPunctStyle.Render(value) + comma
+	case strings.HasPrefix(value, `"`):
+		return syntaxStringStyle.Render(value) + comma
+	default:
+		return syntaxScalarStyle.Render(value) + comma
+	}
+}
+
+// highlightScalar colors a YAML scalar value
+func highlightScalar(value string) string {
+	switch {
+	case value == "":
+		return ""
+	case value == "|" || value == "|-" || value == ">" || value == ">-" || value == "{}" || value == "[]":
+		return syntaxPunctStyle.Render(value)
+	case value == "true" || value == "false" || value == "null" || isNumber(value):
+		return syntaxScalarStyle.Render(value)
+	default:
+		return syntaxStringStyle.Render(value)
+	}
+}
+
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	dot := false
+	for i, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+		case c == '-' && i == 0 && len(s) > 1:
+		case c == '.' && !dot:
+			dot = true
+		default:
+			return false
+		}
+	}
+	return true
+}
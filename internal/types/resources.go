@@ -22,18 +22,44 @@ const (
 	StatusSucceeded ResourceStatus = "Succeeded"
 	StatusFailed    ResourceStatus = "Failed"
 	StatusPending   ResourceStatus = "Pending"
+	StatusSuspended ResourceStatus = "Suspended"
 	StatusUnknown   ResourceStatus = "Unknown"
 )
 
 // DAGNode represents a node in a workflow DAG
 type DAGNode struct {
-	Name   string
-	Type   string // DAG, Pod, Retry, etc.
-	Phase  string // Running, Succeeded, Failed, Pending, Error
+	ID           string
+	Name         string
+	Type         string   // DAG, Pod, Retry, etc.
+	Phase        string   // Running, Succeeded, Failed, Pending, Error
+	TemplateName string   // template the node executes
+	BoundaryID   string   // ID of the enclosing DAG/Steps node
+	Children     []string // IDs of the nodes that depend on this one
+	StartedAt    *time.Time
+	FinishedAt   *time.Time
+	Duration     time.Duration
+	Message      string
+
+	// Execution details (for Pod and Retry nodes)
+	PodName           string
+	HostNodeName      string
+	ExitCode          string
+	Attempts          int // retry attempts aggregated into a Retry node
+	Inputs            []NodeIO
+	Outputs           []NodeIO
+	ResourcesDuration map[string]int64 // resource name -> seconds
+}
+
+// NodeIO represents an input or output parameter/artifact of a DAG node
+type NodeIO struct {
+	Name     string
+	Value    string
+	Artifact bool
 }
 
 // AsyncResource represents a unified view of async processing resources
 type AsyncResource struct {
+	UID        string // object UID, used to track selection across refreshes
 	Kind       ResourceKind
 	Name       string
 	Namespace  string
@@ -54,9 +80,10 @@ type AsyncResource struct {
 	ServiceAccount string // service account name
 	Schedule       string // for CronJob/CronWorkflow
 	Timezone       string // timezone for schedule (e.g., "Asia/Tokyo")
-	LastRun    *time.Time
-	NextRun    *time.Time
-	QueueDepth int // for queue workers
+	LastRun        *time.Time
+	NextRun        *time.Time
+	QueueDepth     int  // for queue workers
+	Suspended      bool // for CronJob/CronWorkflow
 
 	// Parent relationship (for Workflow spawned by CronWorkflow)
 	ParentKind string
@@ -70,6 +97,59 @@ type AsyncResource struct {
 	EventNames      []string // Event names that Sensor listens to
 	EventType       string   // Type of EventSource (webhook, sqs, kafka, etc.)
 	TriggerNames    []string // Trigger names in Sensor
+
+	// Warning Events about the resource and its pods
+	WarningCount int
+	LastWarning  string // "Reason: message" of the most recent Warning
+
+	// Kind-specific values, shown by the columns and details of the kind
+	Attributes map[string]string
+}
+
+// PodInfo describes a pod backing a Job, Workflow node or event controller
+type PodInfo struct {
+	Name         string
+	Namespace    string
+	NodeName     string   // DAG node display name (for Workflows)
+	Containers   []string // init containers first, then regular containers
+	Default      string   // container to show first
+	CreatedAt    time.Time
+	Phase        string
+	Reason       string // pod-level reason (e.g. Evicted, Unschedulable)
+	HostNodeName string
+	Restarts     int
+	Statuses     []ContainerStatus
+}
+
+// ContainerStatus summarizes the state of a single container
+type ContainerStatus struct {
+	Name         string
+	Init         bool
+	State        string // Waiting, Running or Terminated
+	Reason       string
+	Message      string
+	ExitCode     int32
+	Restarts     int
+	LastReason   string // reason of the previous termination
+	LastExitCode int32
+}
+
+// EventInfo is a core Kubernetes Event about a resource or one of its pods
+type EventInfo struct {
+	UID       string
+	Type      string // Normal or Warning
+	Reason    string
+	Message   string
+	Kind      string // kind of the involved object
+	Name      string // name of the involved object
+	Namespace string
+	Count     int
+	FirstSeen time.Time
+	LastSeen  time.Time
+
+	// Workload the event belongs to (the owning Job/Workflow for pods)
+	OwnerKind ResourceKind
+	OwnerName string
 }
 
 // ViewMode represents the current view mode
@@ -80,6 +160,11 @@ const (
 	ViewJobs
 	ViewWorkflows
 	ViewEvents
+	ViewKubeEvents
+
+	// ViewQueues is the Kueue queue overview; it is not a tab and its
+	// kinds are left out of All
+	ViewQueues
 )
 
 func (v ViewMode) String() string {
@@ -90,7 +175,35 @@ func (v ViewMode) String() string {
 		return "Workflows"
 	case ViewEvents:
 		return "Events"
+	case ViewKubeEvents:
+		return "K8s Events"
+	case ViewQueues:
+		return "Queues"
 	default:
 		return "All"
 	}
 }
+
+// Core kinds identified in access checks
+const (
+	KindEvent ResourceKind = "Event"
+	KindPod   ResourceKind = "Pod"
+)
+
+// AccessKey identifies a permission: a verb on a kind in a namespace
+// ("" for all namespaces)
+type AccessKey struct {
+	Namespace string
+	Kind      ResourceKind
+	Verb      string
+}
+
+// Access holds the results of permission checks
+type Access map[AccessKey]bool
+
+// Allowed reports whether a verb is permitted; permissions that were
+// not checked are assumed allowed
+func (a Access) Allowed(namespace string, kind ResourceKind, verb string) bool {
+	allowed, ok := a[AccessKey{Namespace: namespace, Kind: kind, Verb: verb}]
+	return !ok || allowed
+}